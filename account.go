@@ -1,6 +1,9 @@
 package wolfyclient
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // Logout invalidates the current user's session on the server.
 func (c *Client) Logout() (*MessageResponse, error) {
@@ -23,6 +26,7 @@ func (c *Client) GetSelfInfo() (*PlayerInfoResponse, error) {
 	if err := c.do(req, &playerInfo); err != nil {
 		return nil, err
 	}
+	c.observePlayer(&playerInfo)
 	return &playerInfo, nil
 }
 
@@ -85,6 +89,44 @@ func (c *Client) ChangePassword(oldPassword, newPassword string) (*MessageRespon
 	return &resp, nil
 }
 
+// UpdateSettings changes the authenticated user's preferences and returns the updated account.
+// Only the non-nil fields of settings are sent.
+func (c *Client) UpdateSettings(settings UpdateSettingsRequest) (*UserAccountInfo, error) {
+	if settings != (UpdateSettingsRequest{}) {
+		if err := c.doPostForm("/settings", settings, nil); err != nil {
+			return nil, fmt.Errorf("could not update settings: %w", err)
+		}
+	}
+	return c.GetAccountDetails()
+}
+
+// SetAllowFriendRequests sets whether other players can send friend requests to the authenticated user.
+func (c *Client) SetAllowFriendRequests(allow bool) (*UserAccountInfo, error) {
+	return c.UpdateSettings(UpdateSettingsRequest{AllowFriendRequests: &allow})
+}
+
+// SetAllowGroupRequests sets whether other players can invite the authenticated user to groups.
+func (c *Client) SetAllowGroupRequests(allow bool) (*UserAccountInfo, error) {
+	return c.UpdateSettings(UpdateSettingsRequest{AllowGroupRequests: &allow})
+}
+
+// SetAllowNewsletter sets whether the authenticated user receives the newsletter.
+func (c *Client) SetAllowNewsletter(allow bool) (*UserAccountInfo, error) {
+	return c.UpdateSettings(UpdateSettingsRequest{AllowNewsletter: &allow})
+}
+
+// SetFriendsVisibility sets who can see the authenticated user's friend list.
+// The value is passed as is, so a visibility this library does not know yet, e.g. the
+// FriendsVisibility of a previously fetched account, can still be set.
+func (c *Client) SetFriendsVisibility(visibility FriendsVisibility) (*UserAccountInfo, error) {
+	return c.UpdateSettings(UpdateSettingsRequest{FriendsVisibility: &visibility})
+}
+
+// SetLang sets the authenticated user's language, e.g. "fr" or "en".
+func (c *Client) SetLang(lang string) (*UserAccountInfo, error) {
+	return c.UpdateSettings(UpdateSettingsRequest{Lang: &lang})
+}
+
 // UpdateSkinSlot changes the equipped cosmetic items for a specific skin slot.
 // The 'updates' map should contain the skin parts to change, e.g., "top": SkinPart{ID:"002", Color:5}.
 func (c *Client) UpdateSkinSlot(slotID string, updates map[string]SkinPart) (*UpdateSkinSlotResponse, error) {
@@ -97,3 +139,47 @@ func (c *Client) UpdateSkinSlot(slotID string, updates map[string]SkinPart) (*Up
 	}
 	return &resp, nil
 }
+
+// Ban returns the ban state of the account.
+func (u *UserAccountInfo) Ban() BanInfo {
+	ban := BanInfo{Banned: u.Banned, End: u.BanEnd}
+	if u.ReasonBan != nil {
+		ban.Reason = *u.ReasonBan
+	}
+	return ban
+}
+
+// Active reports whether the ban is still in effect.
+func (b BanInfo) Active() bool {
+	if !b.Banned {
+		return false
+	}
+	return b.End.IsZero() || time.Now().Before(b.End.Time)
+}
+
+// LinkedAccounts returns the third-party logins connected to the account.
+func (u *UserAccountInfo) LinkedAccounts() []LinkedAccount {
+	providers := []struct {
+		name string
+		id   *string
+	}{
+		{"twitter", u.TwitterID},
+		{"facebook", u.FacebookID},
+		{"google", u.GoogleID},
+		{"discord", u.DiscordID},
+		{"apple", u.AppleID},
+	}
+
+	var linked []LinkedAccount
+	for _, p := range providers {
+		if p.id != nil && *p.id != "" {
+			linked = append(linked, LinkedAccount{Provider: p.name, ID: *p.id})
+		}
+	}
+	return linked
+}
+
+// HasDiscount reports whether the account currently benefits from a shop discount.
+func (u *UserAccountInfo) HasDiscount() bool {
+	return !u.DiscountEndAt.IsZero() && time.Now().Before(u.DiscountEndAt.Time)
+}
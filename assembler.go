@@ -0,0 +1,275 @@
+package wolfyclient
+
+import (
+	"sort"
+	"sync"
+)
+
+// Participant is a seat of a reconstructed game.
+// Seats known only from other players' death reasons (e.g. as a voter) have Partial set,
+// and carry nothing but their user ID.
+type Participant struct {
+	UserID    string
+	Username  string // Only known for players added through AddPlayer.
+	Role      Role
+	Winner    bool
+	Lovers    bool
+	Infected  bool
+	WordCount int
+	KillCount int
+	Death     *DeathReason
+	Partial   bool
+}
+
+// GameDeath is a death in a reconstructed game.
+type GameDeath struct {
+	UserID      string
+	Day         int
+	Reason      DeathReason
+	Responsible []string
+}
+
+// GameVote is a vote cast against a player who was eliminated by the village.
+type GameVote struct {
+	Day      int
+	VoterID  string
+	TargetID string
+}
+
+// ReconstructedGame is a match rebuilt from the history entries of several of its players.
+type ReconstructedGame struct {
+	Game         Game
+	Participants []Participant // Fully known seats first, then partial ones; sorted by user ID within each group.
+	Deaths       []GameDeath   // Ordered by day.
+	Votes        []GameVote    // Ordered by day.
+	Winners      []string
+	Lovers       []string
+	Infected     []string
+	// UnknownSeats is the number of seats nobody was seen in, not even as a voter.
+	UnknownSeats int
+	// UnassignedRoles counts the roles of the game composition not yet matched to a known participant.
+	UnassignedRoles map[Role]int
+}
+
+// Participant returns the seat of a user in the game.
+func (g *ReconstructedGame) Participant(userID string) (Participant, bool) {
+	for _, p := range g.Participants {
+		if p.UserID == userID {
+			return p, true
+		}
+	}
+	return Participant{}, false
+}
+
+// Complete reports whether every seat of the game is fully known.
+func (g *ReconstructedGame) Complete() bool {
+	if g.UnknownSeats > 0 {
+		return false
+	}
+	for _, p := range g.Participants {
+		if p.Partial {
+			return false
+		}
+	}
+	return true
+}
+
+// GameAssembler merges the history entries of many players into reconstructed games.
+// Each GameHistoryEntry only shows one participant's view of a game; the more players of
+// a game are added, the more complete its reconstruction. It is safe for concurrent use.
+type GameAssembler struct {
+	mu        sync.Mutex
+	games     map[string]Game
+	entries   map[string]map[string]GameHistoryEntry // gameID -> userID -> entry
+	usernames map[string]string
+}
+
+// NewGameAssembler creates an empty assembler.
+func NewGameAssembler() *GameAssembler {
+	return &GameAssembler{
+		games:     make(map[string]Game),
+		entries:   make(map[string]map[string]GameHistoryEntry),
+		usernames: make(map[string]string),
+	}
+}
+
+// AddPlayer adds a fetched profile's history, remembering the player's username.
+func (a *GameAssembler) AddPlayer(info *PlayerInfoResponse) {
+	a.mu.Lock()
+	a.usernames[info.User.ID] = info.User.Username
+	a.mu.Unlock()
+	a.Add(info.History...)
+}
+
+// Add adds history entries, possibly from several players.
+func (a *GameAssembler) Add(entries ...GameHistoryEntry) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, entry := range entries {
+		if entry.Game.ID != "" {
+			a.games[entry.GameID] = entry.Game
+		}
+		seats, ok := a.entries[entry.GameID]
+		if !ok {
+			seats = make(map[string]GameHistoryEntry)
+			a.entries[entry.GameID] = seats
+		}
+		seats[entry.UserID] = entry
+	}
+}
+
+// GameIDs returns the IDs of every game seen, sorted.
+func (a *GameAssembler) GameIDs() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	ids := make([]string, 0, len(a.entries))
+	for id := range a.entries {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// SharedGameIDs returns the IDs of games seen from at least minPlayers players, sorted.
+func (a *GameAssembler) SharedGameIDs(minPlayers int) []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var ids []string
+	for id, seats := range a.entries {
+		if len(seats) >= minPlayers {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// LastDay returns the last day of a game, as the latest day anyone added was seen dying on.
+// Games usually end with a death, but one that ended on a day without any seen death
+// reports an earlier day. It returns false if the game is unknown or no death was seen in it.
+func (a *GameAssembler) LastDay(gameID string) (int, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	last, ok := 0, false
+	for _, entry := range a.entries[gameID] {
+		if entry.DeathReason != nil {
+			last, ok = max(last, entry.DeathReason.DayNumber), true
+		}
+	}
+	return last, ok
+}
+
+// Game reconstructs the game with the given ID from every entry added so far.
+func (a *GameAssembler) Game(gameID string) (*ReconstructedGame, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	seats, ok := a.entries[gameID]
+	if !ok {
+		return nil, false
+	}
+
+	g := &ReconstructedGame{Game: a.games[gameID]}
+	known := make(map[string]bool, len(seats))
+	lovers := make(map[string]bool)
+
+	// 1. Fully known seats, from each participant's own entry.
+	for userID, entry := range seats {
+		known[userID] = true
+		g.Participants = append(g.Participants, Participant{
+			UserID:    userID,
+			Username:  a.usernames[userID],
+			Role:      entry.Role,
+			Winner:    entry.Winner,
+			Lovers:    entry.Lovers,
+			Infected:  entry.Infected,
+			WordCount: entry.WordCount,
+			KillCount: entry.KillCount,
+			Death:     entry.DeathReason,
+		})
+		if entry.Winner {
+			g.Winners = append(g.Winners, userID)
+		}
+		if entry.Lovers {
+			lovers[userID] = true
+		}
+		if entry.Infected {
+			g.Infected = append(g.Infected, userID)
+		}
+
+		if entry.DeathReason == nil {
+			continue
+		}
+		death := *entry.DeathReason
+		g.Deaths = append(g.Deaths, GameDeath{UserID: userID, Day: death.DayNumber, Reason: death, Responsible: death.Responsible()})
+		if death.Type == DeathVote {
+			for _, voter := range death.VotersIDs {
+				g.Votes = append(g.Votes, GameVote{Day: death.DayNumber, VoterID: voter, TargetID: userID})
+			}
+		}
+		// Dying of grief reveals the other lover, even if we never saw their entry.
+		if death.Type == DeathLover && death.LoverID != "" {
+			lovers[userID] = true
+			lovers[death.LoverID] = true
+		}
+	}
+	sort.Slice(g.Participants, func(i, j int) bool { return g.Participants[i].UserID < g.Participants[j].UserID })
+
+	// 2. Partial seats: players that only appear in someone else's death reason.
+	var partial []string
+	for _, d := range g.Deaths {
+		for _, id := range append(d.Responsible, d.Reason.LoverID) {
+			if id != "" && !known[id] {
+				known[id] = true
+				partial = append(partial, id)
+			}
+		}
+	}
+	sort.Strings(partial)
+	for _, id := range partial {
+		g.Participants = append(g.Participants, Participant{UserID: id, Username: a.usernames[id], Lovers: lovers[id], Partial: true})
+	}
+
+	for id := range lovers {
+		g.Lovers = append(g.Lovers, id)
+	}
+	sort.Strings(g.Winners)
+	sort.Strings(g.Lovers)
+	sort.Strings(g.Infected)
+	sort.SliceStable(g.Deaths, func(i, j int) bool { return g.Deaths[i].Day < g.Deaths[j].Day })
+	sort.SliceStable(g.Votes, func(i, j int) bool { return g.Votes[i].Day < g.Votes[j].Day })
+
+	// 3. Seats and roles still unaccounted for.
+	g.UnknownSeats = max(g.Game.PlayerCount-len(g.Participants), 0)
+	g.UnassignedRoles = make(map[Role]int)
+	for role, count := range g.Game.Settings.Roles {
+		g.UnassignedRoles[role] = count
+	}
+	for _, p := range g.Participants {
+		if !p.Partial && g.UnassignedRoles[p.Role] > 0 {
+			g.UnassignedRoles[p.Role]--
+		}
+	}
+	for role, count := range g.UnassignedRoles {
+		if count == 0 {
+			delete(g.UnassignedRoles, role)
+		}
+	}
+
+	return g, true
+}
+
+// Games reconstructs every game seen, sorted by ID.
+func (a *GameAssembler) Games() []*ReconstructedGame {
+	var games []*ReconstructedGame
+	for _, id := range a.GameIDs() {
+		if g, ok := a.Game(id); ok {
+			games = append(games, g)
+		}
+	}
+	return games
+}
@@ -0,0 +1,74 @@
+package wolfyclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// defaultBatchConcurrency is used by batch lookups when SetBatchConcurrency was not called.
+const defaultBatchConcurrency = 4
+
+// PlayerResult is the outcome of one lookup in a batch.
+type PlayerResult struct {
+	Username string
+	Info     *PlayerInfoResponse // Nil if Err is set.
+	Err      error
+}
+
+// GetPlayersInfo fetches the profiles of many players in parallel.
+// It returns one result per username, in the same order; a failed lookup does not stop the others.
+// Lookups respect the client's rate limit and batch concurrency, and usernames repeated in the
+// list, or already being looked up by another goroutine, are only fetched once.
+// If ctx is canceled, the remaining lookups fail with the context's error.
+func (c *Client) GetPlayersInfo(ctx context.Context, usernames []string) []PlayerResult {
+	results := make([]PlayerResult, len(usernames))
+	concurrency := c.batchConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+
+	// Fetch each distinct username once; duplicates are filled in at the end.
+	first := make(map[string]int, len(usernames))
+	var unique []int
+	for i, username := range usernames {
+		if _, seen := first[username]; !seen {
+			first[username] = i
+			unique = append(unique, i)
+		}
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(concurrency, len(unique)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				info, err := c.GetPlayerInfoContext(ctx, usernames[i])
+				if err != nil {
+					err = fmt.Errorf("could not fetch '%s': %w", usernames[i], err)
+				}
+				results[i] = PlayerResult{Username: usernames[i], Info: info, Err: err}
+			}
+		}()
+	}
+
+	for _, i := range unique {
+		if ctx.Err() != nil {
+			results[i] = PlayerResult{Username: usernames[i], Err: ctx.Err()}
+			continue
+		}
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for i, username := range usernames {
+		if j := first[username]; j != i {
+			results[i] = results[j]
+		}
+	}
+
+	return results
+}
@@ -0,0 +1,433 @@
+package wolfyclient
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Catalog is an indexed, in-memory view of the skin catalog returned by GetSkinCatalog.
+// It is read-only once built and safe for concurrent use.
+type Catalog struct {
+	elements   []SkinElement
+	byID       map[string]int
+	byType     map[SkinPartType][]int
+	byRarity   map[Rarity][]int
+	byAccess   map[Access][]int
+	byCurrency map[Currency][]int
+	byLevel    map[int][]int
+}
+
+// NewCatalog builds a catalog and its indexes from a list of skin elements.
+func NewCatalog(elements []SkinElement) *Catalog {
+	c := &Catalog{
+		elements:   elements,
+		byID:       make(map[string]int, len(elements)),
+		byType:     make(map[SkinPartType][]int),
+		byRarity:   make(map[Rarity][]int),
+		byAccess:   make(map[Access][]int),
+		byCurrency: make(map[Currency][]int),
+		byLevel:    make(map[int][]int),
+	}
+	for i, e := range elements {
+		c.byID[e.ID] = i
+		c.byType[e.Type] = append(c.byType[e.Type], i)
+		c.byRarity[e.Rarity] = append(c.byRarity[e.Rarity], i)
+		c.byAccess[e.Access] = append(c.byAccess[e.Access], i)
+		c.byCurrency[e.Currency] = append(c.byCurrency[e.Currency], i)
+		c.byLevel[e.Level] = append(c.byLevel[e.Level], i)
+	}
+	return c
+}
+
+// GetCatalog fetches the skin catalog and returns it as an indexed Catalog.
+func (c *Client) GetCatalog() (*Catalog, error) {
+	elements, err := c.GetSkinCatalog()
+	if err != nil {
+		return nil, err
+	}
+	return NewCatalog(elements), nil
+}
+
+// Len returns the number of elements in the catalog.
+func (c *Catalog) Len() int {
+	return len(c.elements)
+}
+
+// Elements returns every element of the catalog, in the order they were returned by the API.
+func (c *Catalog) Elements() []SkinElement {
+	return append([]SkinElement(nil), c.elements...)
+}
+
+// Get returns the element with the given ID.
+func (c *Catalog) Get(id string) (SkinElement, bool) {
+	i, ok := c.byID[id]
+	if !ok {
+		return SkinElement{}, false
+	}
+	return c.elements[i], true
+}
+
+// ResolveSkinPart returns the color variant used by a skin part.
+func (c *Catalog) ResolveSkinPart(part SkinPart) (ColorVariant, error) {
+	element, ok := c.Get(part.ID)
+	if !ok {
+		return ColorVariant{}, fmt.Errorf("skin element '%s' not found in catalog", part.ID)
+	}
+	return element.ColorVariant(part.Color)
+}
+
+// Query starts a new query over the whole catalog.
+func (c *Catalog) Query() *CatalogQuery {
+	return &CatalogQuery{catalog: c}
+}
+
+// --- Query ---
+
+// CatalogQuery is a fluent query over a Catalog.
+// Indexed filters (Type, Rarity, Access, Currency, Level) narrow the candidate set
+// through the catalog's indexes; Where adds arbitrary predicates on top.
+// A query is not safe for concurrent use, but many queries may run on the same catalog.
+type CatalogQuery struct {
+	catalog    *Catalog
+	candidates []int // nil means every element
+	narrowed   bool
+	filters    []func(SkinElement) bool
+	less       func(a, b SkinElement) bool
+	limit      int
+}
+
+// narrow intersects the current candidates with an index posting list.
+func (q *CatalogQuery) narrow(ids []int) *CatalogQuery {
+	if !q.narrowed {
+		q.candidates = append([]int(nil), ids...)
+		q.narrowed = true
+		return q
+	}
+
+	keep := make(map[int]bool, len(ids))
+	for _, i := range ids {
+		keep[i] = true
+	}
+	filtered := q.candidates[:0]
+	for _, i := range q.candidates {
+		if keep[i] {
+			filtered = append(filtered, i)
+		}
+	}
+	q.candidates = filtered
+	return q
+}
+
+// union merges the posting lists of several index keys, keeping catalog order.
+// Repeated keys are only counted once.
+func union[K comparable](index map[K][]int, keys []K) []int {
+	var ids []int
+	seen := make(map[K]bool, len(keys))
+	for _, key := range keys {
+		if !seen[key] {
+			seen[key] = true
+			ids = append(ids, index[key]...)
+		}
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+// narrowBy narrows the query to the elements indexed under any of the keys.
+// An empty key list leaves the query unfiltered.
+func narrowBy[K comparable](q *CatalogQuery, index map[K][]int, keys []K) *CatalogQuery {
+	if len(keys) == 0 {
+		return q
+	}
+	return q.narrow(union(index, keys))
+}
+
+// Type keeps elements of any of the given types, e.g. SkinPartHair.
+// Called without types, it keeps every element; the same goes for the other indexed filters.
+func (q *CatalogQuery) Type(types ...SkinPartType) *CatalogQuery {
+	return narrowBy(q, q.catalog.byType, types)
+}
+
+// Rarity keeps elements of any of the given rarities.
+func (q *CatalogQuery) Rarity(rarities ...Rarity) *CatalogQuery {
+	return narrowBy(q, q.catalog.byRarity, rarities)
+}
+
+// Access keeps elements with any of the given access modes.
+func (q *CatalogQuery) Access(access ...Access) *CatalogQuery {
+	return narrowBy(q, q.catalog.byAccess, access)
+}
+
+// Currency keeps elements sold in any of the given currencies.
+func (q *CatalogQuery) Currency(currencies ...Currency) *CatalogQuery {
+	return narrowBy(q, q.catalog.byCurrency, currencies)
+}
+
+// Level keeps elements whose level is between minLevel and maxLevel, inclusive.
+func (q *CatalogQuery) Level(minLevel, maxLevel int) *CatalogQuery {
+	var ids []int
+	for level, posting := range q.catalog.byLevel {
+		if level >= minLevel && level <= maxLevel {
+			ids = append(ids, posting...)
+		}
+	}
+	sort.Ints(ids)
+	return q.narrow(ids)
+}
+
+// Where keeps elements matching the predicate.
+func (q *CatalogQuery) Where(predicate func(SkinElement) bool) *CatalogQuery {
+	q.filters = append(q.filters, predicate)
+	return q
+}
+
+// OrderBy sorts the results using the given less function. The sort is stable,
+// so elements that compare equal keep their catalog order.
+func (q *CatalogQuery) OrderBy(less func(a, b SkinElement) bool) *CatalogQuery {
+	q.less = less
+	return q
+}
+
+// Limit caps the number of results. Zero means no limit.
+func (q *CatalogQuery) Limit(n int) *CatalogQuery {
+	q.limit = n
+	return q
+}
+
+// All runs the query and returns the matching elements.
+func (q *CatalogQuery) All() []SkinElement {
+	var results []SkinElement
+	visit := func(i int) {
+		e := q.catalog.elements[i]
+		for _, filter := range q.filters {
+			if !filter(e) {
+				return
+			}
+		}
+		results = append(results, e)
+	}
+
+	if q.narrowed {
+		for _, i := range q.candidates {
+			visit(i)
+		}
+	} else {
+		for i := range q.catalog.elements {
+			visit(i)
+		}
+	}
+
+	if q.less != nil {
+		sort.SliceStable(results, func(i, j int) bool { return q.less(results[i], results[j]) })
+	}
+	if q.limit > 0 && len(results) > q.limit {
+		results = results[:q.limit]
+	}
+	return results
+}
+
+// First runs the query and returns its first result.
+func (q *CatalogQuery) First() (SkinElement, bool) {
+	limit := q.limit
+	q.limit = 1
+	results := q.All()
+	q.limit = limit
+	if len(results) == 0 {
+		return SkinElement{}, false
+	}
+	return results[0], true
+}
+
+// Count runs the query and returns the number of matching elements, ignoring Limit.
+func (q *CatalogQuery) Count() int {
+	limit := q.limit
+	q.limit = 0
+	n := len(q.All())
+	q.limit = limit
+	return n
+}
+
+// Common orderings to use with OrderBy.
+var (
+	CatalogByName  = func(a, b SkinElement) bool { return normalizeName(a.Name) < normalizeName(b.Name) }
+	CatalogByPrice = func(a, b SkinElement) bool { return a.Price < b.Price }
+	CatalogByLevel = func(a, b SkinElement) bool { return a.Level < b.Level }
+	// CatalogByRarity orders from common to legendary, with unknown rarities first.
+	CatalogByRarity = func(a, b SkinElement) bool { return a.Rarity.Less(b.Rarity) }
+)
+
+// --- Name Search ---
+
+// CatalogMatch is a single name search result.
+type CatalogMatch struct {
+	Element SkinElement
+	// Distance is 0 for an exact match, 1 for a prefix match, 2 for a substring match,
+	// and 3 or more for fuzzy matches, growing with the number of edits needed.
+	Distance int
+}
+
+// Search finds elements whose name matches the query, ignoring case and accents.
+// Exact, prefix and substring matches always qualify; other names qualify when
+// one of their words is within a small edit distance of the query, to tolerate typos.
+// Results are ordered from best to worst match.
+func (c *Catalog) Search(query string) []CatalogMatch {
+	q := normalizeName(query)
+	if q == "" {
+		return nil
+	}
+	maxEdits := max(1, utf8.RuneCountInString(q)/3)
+
+	var matches []CatalogMatch
+	for _, e := range c.elements {
+		name := normalizeName(e.Name)
+		switch {
+		case name == q:
+			matches = append(matches, CatalogMatch{Element: e, Distance: 0})
+		case strings.HasPrefix(name, q):
+			matches = append(matches, CatalogMatch{Element: e, Distance: 1})
+		case strings.Contains(name, q):
+			matches = append(matches, CatalogMatch{Element: e, Distance: 2})
+		default:
+			best := levenshtein(q, name)
+			for _, word := range strings.Fields(name) {
+				best = min(best, levenshtein(q, word))
+			}
+			if best <= maxEdits {
+				matches = append(matches, CatalogMatch{Element: e, Distance: 2 + best})
+			}
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Distance < matches[j].Distance })
+	return matches
+}
+
+// accentReplacer folds the accented letters found in Wolfy's (mostly French) item names.
+var accentReplacer = strings.NewReplacer(
+	"à", "a", "â", "a", "ä", "a", "á", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"î", "i", "ï", "i", "í", "i",
+	"ô", "o", "ö", "o", "ó", "o",
+	"ù", "u", "û", "u", "ü", "u", "ú", "u",
+	"ç", "c", "ñ", "n", "œ", "oe", "æ", "ae",
+)
+
+// normalizeName lowercases a name, removes its accents and collapses whitespace.
+func normalizeName(name string) string {
+	return strings.Join(strings.Fields(accentReplacer.Replace(strings.ToLower(name))), " ")
+}
+
+// levenshtein returns the edit distance between two strings, counted in runes.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// --- Joins ---
+
+// CatalogDropMatch links a catalog element to its appearance in a drop pack.
+type CatalogDropMatch struct {
+	Element SkinElement
+	Drop    DropSkinElement
+	Pack    DropPack
+}
+
+// JoinDrop returns, for every element of the drop's packs that exists in the catalog,
+// the catalog element alongside its drop entry and pack.
+func (c *Catalog) JoinDrop(drop *CurrentDrop) []CatalogDropMatch {
+	var matches []CatalogDropMatch
+	for _, pack := range drop.Packs {
+		for _, dropElement := range pack.SkinElements {
+			if e, ok := c.Get(dropElement.ID); ok {
+				matches = append(matches, CatalogDropMatch{Element: e, Drop: dropElement, Pack: pack})
+			}
+		}
+	}
+	return matches
+}
+
+// CatalogOfferMatch links a catalog element to a daily offer it is sold in.
+type CatalogOfferMatch struct {
+	Element  SkinElement
+	Category string // The offer category, e.g. "coinsLow".
+	Offer    OfferElement
+	Pack     *OfferPack // Set when the element is sold as part of a pack.
+	OfferSet int        // ID of the DailyOfferSet.
+}
+
+// JoinOffers returns every catalog element that is offered in the given daily offer sets,
+// either on its own or as part of a pack.
+func (c *Catalog) JoinOffers(offers []DailyOfferSet) []CatalogOfferMatch {
+	var matches []CatalogOfferMatch
+	for _, set := range offers {
+		for _, category := range set.Elements.Categories() {
+			offer := set.Elements.Get(category)
+			if offer.Skin != nil {
+				if e, ok := c.Get(offer.Skin.ID); ok {
+					matches = append(matches, CatalogOfferMatch{Element: e, Category: category, Offer: offer, OfferSet: set.ID})
+				}
+			}
+			if offer.Pack != nil {
+				for _, packElement := range offer.Pack.SkinElements {
+					if e, ok := c.Get(packElement.ID); ok {
+						matches = append(matches, CatalogOfferMatch{Element: e, Category: category, Offer: offer, Pack: offer.Pack, OfferSet: set.ID})
+					}
+				}
+			}
+		}
+	}
+	return matches
+}
+
+// Categories returns the names of every offer category, as used in the JSON payload.
+func (o OfferElements) Categories() []string {
+	return []string{
+		"free", "coinsLow", "coinsHigh", "moonsLow", "moonsMedium",
+		"moonsHigh", "moonsUltraHigh", "collectionLow", "collectionHigh", "premium",
+	}
+}
+
+// Get returns the offer for a category name, or an empty offer if the name is unknown.
+func (o OfferElements) Get(category string) OfferElement {
+	switch category {
+	case "free":
+		return o.Free
+	case "coinsLow":
+		return o.CoinsLow
+	case "coinsHigh":
+		return o.CoinsHigh
+	case "moonsLow":
+		return o.MoonsLow
+	case "moonsMedium":
+		return o.MoonsMedium
+	case "moonsHigh":
+		return o.MoonsHigh
+	case "moonsUltraHigh":
+		return o.MoonsUltraHigh
+	case "collectionLow":
+		return o.CollectionLow
+	case "collectionHigh":
+		return o.CollectionHigh
+	case "premium":
+		return o.Premium
+	}
+	return OfferElement{}
+}
@@ -2,7 +2,9 @@ package wolfyclient
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -23,6 +25,16 @@ type Client struct {
 	httpClient *http.Client
 	// ADD THIS: A map to hold headers that will be sent with every request.
 	defaultHeaders map[string]string
+	// skinCache, when set, serves GetUserSkin from previously fetched renders.
+	skinCache *SkinCache
+	// unknownStatKeys, when set, is notified of advanced stat keys missing from the registry.
+	unknownStatKeys func(player PlayerUser, err *UnknownStatKeysError)
+	// limiter, when set, spaces out requests sent to the server.
+	limiter *rateLimiter
+	// batchConcurrency is the number of parallel requests used by batch lookups.
+	batchConcurrency int
+	// playerFlights deduplicates concurrent profile lookups for the same username.
+	playerFlights flightGroup
 }
 
 // NewClient creates and new, authenticated API client.
@@ -72,6 +84,37 @@ func (c *Client) SetSessionCookie(token string) {
 	c.httpClient.Jar.SetCookies(c.baseURL, []*http.Cookie{cookie})
 }
 
+// SetSkinCache enables caching of skin renders for GetUserSkin.
+// Player lookups made through this client keep the cache up to date, so renders
+// are invalidated as soon as a player's skin version changes. Pass nil to disable caching.
+func (c *Client) SetSkinCache(cache *SkinCache) {
+	c.skinCache = cache
+}
+
+// SetUnknownStatKeyHandler enables validation of advanced stat keys.
+// Every player profile fetched through this client is checked against the stat key registry,
+// and the handler is called whenever it contains keys the registry does not know about,
+// which usually means Wolfy added a new metric. Pass nil to disable validation.
+func (c *Client) SetUnknownStatKeyHandler(handler func(player PlayerUser, err *UnknownStatKeysError)) {
+	c.unknownStatKeys = handler
+}
+
+// SetRateLimit limits the rate of requests sent by this client to requestsPerSecond,
+// allowing short bursts of up to burst requests. Pass a rate of zero or less to remove the limit.
+func (c *Client) SetRateLimit(requestsPerSecond float64, burst int) {
+	if requestsPerSecond <= 0 {
+		c.limiter = nil
+		return
+	}
+	c.limiter = newRateLimiter(requestsPerSecond, burst)
+}
+
+// SetBatchConcurrency sets how many requests batch lookups such as GetPlayersInfo run in parallel.
+// The rate limit, if any, still applies to every request.
+func (c *Client) SetBatchConcurrency(n int) {
+	c.batchConcurrency = n
+}
+
 // --- Internal Helper Methods ---
 
 func (c *Client) newRequest(method, path string, body io.Reader) (*http.Request, error) {
@@ -94,8 +137,24 @@ func (c *Client) newRequest(method, path string, body io.Reader) (*http.Request,
 	return req, nil
 }
 
+// send waits for the rate limiter, if any, then sends the request with the client's HTTP client.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	if err := c.wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return c.httpClient.Do(req)
+}
+
+// wait blocks until the rate limiter allows a new request.
+func (c *Client) wait(ctx context.Context) error {
+	if c.limiter == nil {
+		return nil
+	}
+	return c.limiter.wait(ctx)
+}
+
 func (c *Client) do(req *http.Request, v interface{}) error {
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
 		return err
 	}
@@ -106,7 +165,7 @@ func (c *Client) do(req *http.Request, v interface{}) error {
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		// If the status is not OK, read the body as plain text for a better error message.
 		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("api request failed with status %s: %s", resp.Status, string(bodyBytes))
+		return &APIError{StatusCode: resp.StatusCode, Status: resp.Status, Body: string(bodyBytes)}
 	}
 
 	// If a struct was provided to decode into...
@@ -122,6 +181,23 @@ func (c *Client) do(req *http.Request, v interface{}) error {
 	return nil
 }
 
+// APIError is returned when the server answers a request with a non-2xx status.
+type APIError struct {
+	StatusCode int
+	Status     string // e.g. "404 Not Found"
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("api request failed with status %s: %s", e.Status, e.Body)
+}
+
+// IsNotFound reports whether err, or an error it wraps, is an APIError with status 404.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
 func (c *Client) doPostForm(path string, payload, v interface{}) error {
 	var bodyReader io.Reader
 	if payload != nil {
@@ -0,0 +1,177 @@
+package wolfyclient
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"strconv"
+	"strings"
+)
+
+// ColorVariant is one of the color choices available for a skin element.
+// Index is the value to use in SkinPart.Color, and Hex holds the colors of each
+// layer of the element in that variant (most elements have a single layer).
+type ColorVariant struct {
+	Index int
+	Hex   []string
+}
+
+// Primary returns the first color of the variant, or an empty string if it has none.
+func (v ColorVariant) Primary() string {
+	if len(v.Hex) == 0 {
+		return ""
+	}
+	return v.Hex[0]
+}
+
+// RGBA parses every color of the variant.
+func (v ColorVariant) RGBA() ([]color.RGBA, error) {
+	colors := make([]color.RGBA, len(v.Hex))
+	for i, hex := range v.Hex {
+		c, err := ParseHexColor(hex)
+		if err != nil {
+			return nil, err
+		}
+		colors[i] = c
+	}
+	return colors, nil
+}
+
+// ColorVariants lists the color variants available for the element, in SkinPart.Color order.
+func (e SkinElement) ColorVariants() []ColorVariant {
+	variants := make([]ColorVariant, len(e.Colors))
+	for i, hex := range e.Colors {
+		variants[i] = ColorVariant{Index: i, Hex: hex}
+	}
+	return variants
+}
+
+// ColorVariant returns the variant with the given index, as used in SkinPart.Color.
+func (e SkinElement) ColorVariant(index int) (ColorVariant, error) {
+	if index < 0 || index >= len(e.Colors) {
+		return ColorVariant{}, fmt.Errorf("skin element '%s' has no color %d (%d variants available)", e.ID, index, len(e.Colors))
+	}
+	return ColorVariant{Index: index, Hex: e.Colors[index]}, nil
+}
+
+// NearestColorVariant returns the variant of the element whose colors come closest to the target.
+// A variant's distance is that of its closest layer color, so multi-layer elements match on any layer.
+func (e SkinElement) NearestColorVariant(target color.Color) (ColorVariant, error) {
+	best, bestDistance := -1, 0.0
+	for i, hexColors := range e.Colors {
+		for _, hex := range hexColors {
+			c, err := ParseHexColor(hex)
+			if err != nil {
+				return ColorVariant{}, err
+			}
+			if d := colorDistance(c, target); best == -1 || d < bestDistance {
+				best, bestDistance = i, d
+			}
+		}
+	}
+	if best == -1 {
+		return ColorVariant{}, fmt.Errorf("skin element '%s' has no color variants", e.ID)
+	}
+	return ColorVariant{Index: best, Hex: e.Colors[best]}, nil
+}
+
+// ResolveSkinPart looks the part's item up in the catalog and returns the color variant it uses.
+func ResolveSkinPart(catalog []SkinElement, part SkinPart) (ColorVariant, error) {
+	for _, element := range catalog {
+		if element.ID == part.ID {
+			return element.ColorVariant(part.Color)
+		}
+	}
+	return ColorVariant{}, fmt.Errorf("skin element '%s' not found in catalog", part.ID)
+}
+
+// Parts returns the skin's components keyed by the names used in UpdateSkinSlot, e.g. "top".
+func (s Skin) Parts() map[string]SkinPart {
+	return map[string]SkinPart{
+		string(SkinPartEyes):      s.Eyes,
+		string(SkinPartFace):      s.Face,
+		string(SkinPartHair):      s.Hair,
+		string(SkinPartNose):      s.Nose,
+		string(SkinPartTop):       s.Top,
+		string(SkinPartBottom):    s.Bottom,
+		string(SkinPartShoes):     s.Shoes,
+		string(SkinPartTombstone): s.Tombstone,
+		string(SkinPartGlasses):   s.Glasses,
+	}
+}
+
+// ParseHexColor parses a color written as "#rrggbb", "#rgb" or "#rrggbbaa", with or without the leading '#'.
+func ParseHexColor(hex string) (color.RGBA, error) {
+	s := strings.TrimPrefix(strings.TrimSpace(hex), "#")
+	if len(s) == 3 {
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	}
+	if len(s) == 6 {
+		s += "ff"
+	}
+	if len(s) != 8 {
+		return color.RGBA{}, fmt.Errorf("invalid hex color '%s'", hex)
+	}
+
+	value, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return color.RGBA{}, fmt.Errorf("invalid hex color '%s': %w", hex, err)
+	}
+	return color.RGBA{R: uint8(value >> 24), G: uint8(value >> 16), B: uint8(value >> 8), A: uint8(value)}, nil
+}
+
+// HexColor formats a color as "#rrggbb", ignoring its alpha channel.
+func HexColor(c color.Color) string {
+	rgba := color.RGBAModel.Convert(c).(color.RGBA)
+	return fmt.Sprintf("#%02x%02x%02x", rgba.R, rgba.G, rgba.B)
+}
+
+// colorDistance returns the perceptual distance between two colors using the "redmean" approximation,
+// which weighs the channels according to how sensitive the eye is to them.
+func colorDistance(a, b color.Color) float64 {
+	ca := color.RGBAModel.Convert(a).(color.RGBA)
+	cb := color.RGBAModel.Convert(b).(color.RGBA)
+
+	rMean := (float64(ca.R) + float64(cb.R)) / 2
+	dr := float64(ca.R) - float64(cb.R)
+	dg := float64(ca.G) - float64(cb.G)
+	db := float64(ca.B) - float64(cb.B)
+	return (2+rMean/256)*dr*dr + 4*dg*dg + (2+(255-rMean)/256)*db*db
+}
+
+// RenderSwatch draws a square swatch of the given size for a color variant.
+// Multi-layer variants are drawn as vertical stripes, one per layer.
+func RenderSwatch(variant ColorVariant, size int) (image.Image, error) {
+	colors, err := variant.RGBA()
+	if err != nil {
+		return nil, err
+	}
+
+	swatch := image.NewRGBA(image.Rect(0, 0, size, size))
+	for i, c := range colors {
+		stripe := image.Rect(i*size/len(colors), 0, (i+1)*size/len(colors), size)
+		draw.Draw(swatch, stripe, image.NewUniform(c), image.Point{}, draw.Src)
+	}
+	return swatch, nil
+}
+
+// RenderColorVariants draws every color variant of the element side by side,
+// in index order, each as a swatch of the given size separated by a small gap.
+func RenderColorVariants(element SkinElement, size int) (image.Image, error) {
+	variants := element.ColorVariants()
+	if len(variants) == 0 {
+		return nil, fmt.Errorf("skin element '%s' has no color variants", element.ID)
+	}
+
+	const gap = 2
+	strip := image.NewRGBA(image.Rect(0, 0, len(variants)*(size+gap)-gap, size))
+	for i, variant := range variants {
+		swatch, err := RenderSwatch(variant, size)
+		if err != nil {
+			return nil, err
+		}
+		draw.Draw(strip, swatch.Bounds().Add(image.Pt(i*(size+gap), 0)), swatch, image.Point{}, draw.Src)
+	}
+	return strip, nil
+}
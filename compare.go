@@ -0,0 +1,228 @@
+package wolfyclient
+
+import (
+	"fmt"
+	"sort"
+)
+
+// StatComparison holds the value of one statistic for two players.
+type StatComparison struct {
+	A float64
+	B float64
+}
+
+// Diff returns A minus B.
+func (s StatComparison) Diff() float64 {
+	return s.A - s.B
+}
+
+// Leader returns -1 if A is ahead, 1 if B is ahead, and 0 on a tie.
+func (s StatComparison) Leader() int {
+	switch {
+	case s.A > s.B:
+		return -1
+	case s.B > s.A:
+		return 1
+	}
+	return 0
+}
+
+// RoleComparison compares the win rates of two players with a role.
+// HasA and HasB tell whether each player has statistics for it at all.
+type RoleComparison struct {
+	Role    Role
+	WinRate StatComparison
+	HasA    bool
+	HasB    bool
+}
+
+// AlignmentComparison compares two players' results in one camp.
+type AlignmentComparison struct {
+	WinRate        StatComparison
+	DaysAlive      StatComparison
+	Inactivity     StatComparison
+	Mayor          StatComparison
+	GoodVote       StatComparison
+	InnocentKilled StatComparison
+}
+
+// SharedGame is a game found in both players' histories.
+type SharedGame struct {
+	GameID string
+	A      GameHistoryEntry
+	B      GameHistoryEntry
+}
+
+// gameSide returns the camp a player ended the game in: infected players
+// join the werewolves whatever their role.
+func gameSide(entry GameHistoryEntry) Alignment {
+	if entry.Infected {
+		return AlignmentThreat
+	}
+	return entry.Role.Alignment()
+}
+
+// SameSide reports whether both players played for the same side.
+// Lovers play for their couple: two lovers are on the same side, and a lover is on no
+// one else's. Otherwise the players' camps are compared, counting infected players as
+// werewolves; solo roles are on no one's side, and unknown roles on no known side.
+func (g SharedGame) SameSide() bool {
+	if g.A.Lovers || g.B.Lovers {
+		return g.A.Lovers && g.B.Lovers
+	}
+	a, b := gameSide(g.A), gameSide(g.B)
+	return a == b && a != AlignmentSolo && a != AlignmentUnknown
+}
+
+// Opposed reports whether the players are known to have played against each other.
+func (g SharedGame) Opposed() bool {
+	if g.SameSide() {
+		return false
+	}
+	return gameSide(g.A) != AlignmentUnknown && gameSide(g.B) != AlignmentUnknown
+}
+
+// HeadToHead is the record of two players in the games they played together.
+type HeadToHead struct {
+	Games    []SharedGame
+	BothWon  int // Games played on the same side and won.
+	BothLost int // Games played on the same side and lost.
+	AWon     int // Games played against each other that A won and B lost.
+	BWon     int // Games played against each other that B won and A lost.
+	// Undecided counts the other games: opponents who both lost to a third side,
+	// and games where a role's camp is unknown.
+	Undecided int
+}
+
+// Comparison is a structured diff of two players' profiles.
+type Comparison struct {
+	A PlayerUser
+	B PlayerUser
+
+	Elo        StatComparison
+	XP         StatComparison
+	GamePlayed StatComparison
+	WinCount   StatComparison
+	KillAvg    StatComparison // Kills per game played.
+	WordAvg    StatComparison
+
+	Innocent AlignmentComparison
+	Threat   AlignmentComparison
+
+	Roles   []RoleComparison          // Sorted by role ID.
+	Laurels map[string]StatComparison // Keyed by laurel name.
+
+	HeadToHead HeadToHead
+}
+
+// Compare fetches two players by username and compares their profiles.
+func (c *Client) Compare(usernameA, usernameB string) (*Comparison, error) {
+	a, err := c.GetPlayerInfo(usernameA)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch '%s': %w", usernameA, err)
+	}
+	b, err := c.GetPlayerInfo(usernameB)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch '%s': %w", usernameB, err)
+	}
+	return ComparePlayers(a, b), nil
+}
+
+// ComparePlayers compares two already fetched profiles.
+func ComparePlayers(a, b *PlayerInfoResponse) *Comparison {
+	sa, sb := a.Statistics, b.Statistics
+	cmp := &Comparison{
+		A:          a.User,
+		B:          b.User,
+		Elo:        StatComparison{float64(a.User.Elo), float64(b.User.Elo)},
+		XP:         StatComparison{float64(a.User.XP), float64(b.User.XP)},
+		GamePlayed: StatComparison{float64(a.User.GamePlayed), float64(b.User.GamePlayed)},
+		WinCount:   StatComparison{float64(sa.Individual.WinCount), float64(sb.Individual.WinCount)},
+		KillAvg:    StatComparison{perGame(sa.Individual.KillCount, a.User.GamePlayed), perGame(sb.Individual.KillCount, b.User.GamePlayed)},
+		WordAvg:    StatComparison{sa.Individual.WordAvg, sb.Individual.WordAvg},
+		Innocent:   compareAlignment(sa.Game.Innocent, sb.Game.Innocent),
+		Threat:     compareAlignment(sa.Game.Threat, sb.Game.Threat),
+		Laurels:    make(map[string]StatComparison),
+	}
+
+	// Roles: union of both players' roles.
+	roles := make(map[Role]*RoleComparison)
+	for _, stats := range sa.Roles {
+		roles[stats.ID] = &RoleComparison{Role: stats.ID, WinRate: StatComparison{A: stats.WinRate}, HasA: true}
+	}
+	for _, stats := range sb.Roles {
+		rc, ok := roles[stats.ID]
+		if !ok {
+			rc = &RoleComparison{Role: stats.ID}
+			roles[stats.ID] = rc
+		}
+		rc.WinRate.B = stats.WinRate
+		rc.HasB = true
+	}
+	for _, rc := range roles {
+		cmp.Roles = append(cmp.Roles, *rc)
+	}
+	sort.Slice(cmp.Roles, func(i, j int) bool { return cmp.Roles[i].Role < cmp.Roles[j].Role })
+
+	// Laurels: union of both players' laurels, missing ones counting as zero.
+	for name, count := range sa.Laurels {
+		cmp.Laurels[name] = StatComparison{A: float64(count)}
+	}
+	for name, count := range sb.Laurels {
+		laurel := cmp.Laurels[name]
+		laurel.B = float64(count)
+		cmp.Laurels[name] = laurel
+	}
+
+	cmp.HeadToHead = headToHead(a.History, b.History)
+	return cmp
+}
+
+func compareAlignment(a, b GameTypeStats) AlignmentComparison {
+	return AlignmentComparison{
+		WinRate:        StatComparison{a.WinRate, b.WinRate},
+		DaysAlive:      StatComparison{a.AdvancedStats.DaysAlive, b.AdvancedStats.DaysAlive},
+		Inactivity:     StatComparison{a.AdvancedStats.Inactivity, b.AdvancedStats.Inactivity},
+		Mayor:          StatComparison{a.AdvancedStats.Mayor, b.AdvancedStats.Mayor},
+		GoodVote:       StatComparison{a.AdvancedStats.GoodVote, b.AdvancedStats.GoodVote},
+		InnocentKilled: StatComparison{a.AdvancedStats.InnocentKilled, b.AdvancedStats.InnocentKilled},
+	}
+}
+
+// headToHead matches two histories on their game IDs, newest game first as in the API.
+func headToHead(a, b []GameHistoryEntry) HeadToHead {
+	byGame := make(map[string]GameHistoryEntry, len(b))
+	for _, entry := range b {
+		byGame[entry.GameID] = entry
+	}
+
+	var h HeadToHead
+	for _, entryA := range a {
+		entryB, ok := byGame[entryA.GameID]
+		if !ok {
+			continue
+		}
+		h.Games = append(h.Games, SharedGame{GameID: entryA.GameID, A: entryA, B: entryB})
+		game := h.Games[len(h.Games)-1]
+		switch {
+		case game.SameSide() && entryA.Winner:
+			h.BothWon++
+		case game.SameSide():
+			h.BothLost++
+		case game.Opposed() && entryA.Winner && !entryB.Winner:
+			h.AWon++
+		case game.Opposed() && entryB.Winner && !entryA.Winner:
+			h.BWon++
+		default:
+			h.Undecided++
+		}
+	}
+	return h
+}
+
+func perGame(total, games int) float64 {
+	if games == 0 {
+		return 0
+	}
+	return float64(total) / float64(games)
+}
@@ -0,0 +1,370 @@
+package wolfyclient
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GraphEdgeKind is the kind of relationship an edge of the social graph stands for.
+type GraphEdgeKind string
+
+const (
+	EdgeFriend   GraphEdgeKind = "friend"   // The two players are friends.
+	EdgeCoPlayed GraphEdgeKind = "coplayed" // The two players were seen in the same games.
+)
+
+// GraphNode is a player of the social graph.
+type GraphNode struct {
+	ID       string `json:"id"`
+	Username string `json:"username,omitempty"` // Empty if the player was only seen by ID.
+	Depth    int    `json:"depth"`              // Distance from the nearest seed.
+	Fetched  bool   `json:"fetched"`            // Whether the player's profile was crawled.
+	Elo      int    `json:"elo,omitempty"`
+	Rank     int    `json:"rank,omitempty"`
+}
+
+// GraphEdge is an undirected relationship between two players.
+// Weight counts the shared games for co-play edges, and is 1 for friend edges.
+type GraphEdge struct {
+	From   string        `json:"from"`
+	To     string        `json:"to"`
+	Kind   GraphEdgeKind `json:"kind"`
+	Weight int           `json:"weight"`
+}
+
+// SocialGraph is a graph of players and their relationships.
+type SocialGraph struct {
+	nodes map[string]*GraphNode
+	edges map[string]*GraphEdge
+}
+
+// NewSocialGraph creates an empty graph.
+func NewSocialGraph() *SocialGraph {
+	return &SocialGraph{
+		nodes: make(map[string]*GraphNode),
+		edges: make(map[string]*GraphEdge),
+	}
+}
+
+// node returns the node for an ID, creating it at the given depth if needed.
+func (g *SocialGraph) node(id string, depth int) *GraphNode {
+	n, ok := g.nodes[id]
+	if !ok {
+		n = &GraphNode{ID: id, Depth: depth}
+		g.nodes[id] = n
+	}
+	n.Depth = min(n.Depth, depth)
+	return n
+}
+
+// link adds weight to the edge between two players, creating it if needed.
+func (g *SocialGraph) link(a, b string, kind GraphEdgeKind, weight int) {
+	if a == b {
+		return
+	}
+	if a > b {
+		a, b = b, a
+	}
+	key := a + "|" + b + "|" + string(kind)
+	e, ok := g.edges[key]
+	if !ok {
+		e = &GraphEdge{From: a, To: b, Kind: kind}
+		g.edges[key] = e
+	}
+	e.Weight += weight
+}
+
+// Nodes returns every node, sorted by depth and then ID.
+func (g *SocialGraph) Nodes() []GraphNode {
+	nodes := make([]GraphNode, 0, len(g.nodes))
+	for _, n := range g.nodes {
+		nodes = append(nodes, *n)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].Depth != nodes[j].Depth {
+			return nodes[i].Depth < nodes[j].Depth
+		}
+		return nodes[i].ID < nodes[j].ID
+	})
+	return nodes
+}
+
+// Edges returns every edge, sorted by endpoints and kind.
+func (g *SocialGraph) Edges() []GraphEdge {
+	edges := make([]GraphEdge, 0, len(g.edges))
+	for _, e := range g.edges {
+		edges = append(edges, *e)
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		if edges[i].To != edges[j].To {
+			return edges[i].To < edges[j].To
+		}
+		return edges[i].Kind < edges[j].Kind
+	})
+	return edges
+}
+
+// --- Crawler ---
+
+// Crawler explores the community breadth-first from a set of seed players.
+//
+// Wolfy only exposes the friend list of the authenticated user, so friend edges come from
+// its friend leaderboard. Every other edge comes from game histories: players are linked when
+// they share a GameID, which includes appearing in the other's death reason (as a voter, hunter,
+// mayor or lover). Players discovered by ID can only be crawled further once their username is
+// known, either from the friend leaderboard or from the resolver's cache.
+type Crawler struct {
+	client   *Client
+	resolver *Resolver
+
+	// MaxDepth is the number of hops to explore from the seeds. Zero only crawls the seeds.
+	MaxDepth int
+	// MaxProfiles caps the number of profile lookups, failed ones included, so that
+	// it also bounds the requests sent. Zero means no limit.
+	MaxProfiles int
+	// IncludeFriends adds the authenticated user and its friends to the graph.
+	// The authenticated user counts as a seed, and its friends are crawled as its neighbours.
+	IncludeFriends bool
+}
+
+// NewCrawler creates a crawler. The resolver may be nil, in which case only usernames
+// learned during the crawl are used.
+func NewCrawler(client *Client, resolver *Resolver) *Crawler {
+	if resolver == nil {
+		resolver = NewResolver(client, 0)
+	}
+	return &Crawler{client: client, resolver: resolver, MaxDepth: 1}
+}
+
+// Crawl explores the graph from the seed usernames and returns it.
+// Profiles are fetched level by level with GetPlayersInfo, so the client's rate limit and
+// batch concurrency apply. Profiles that fail to load are left unfetched in the graph.
+func (c *Crawler) Crawl(ctx context.Context, seeds []string) (*SocialGraph, error) {
+	graph := NewSocialGraph()
+	assembler := NewGameAssembler()
+	attempted := 0 // Profile lookups so far, successful or not.
+	visited := make(map[string]bool)
+	var friends []string // Usernames of the authenticated user's friends, crawled at depth 1.
+
+	// 1. Friend edges, from the authenticated user's point of view.
+	if c.IncludeFriends {
+		self, err := c.client.GetSelfInfo()
+		if err != nil {
+			return nil, err
+		}
+		leaderboard, err := c.client.GetFriendLeaderboard()
+		if err != nil {
+			return nil, err
+		}
+		c.resolver.ObservePlayer(self)
+		c.resolver.ObserveLeaderboard(leaderboard)
+
+		attempted++
+		assembler.AddPlayer(self)
+		visited[strings.ToLower(self.User.Username)] = true
+		n := graph.node(self.User.ID, 0)
+		n.Username, n.Elo, n.Rank, n.Fetched = self.User.Username, self.User.Elo, self.User.Rank, true
+
+		for _, entry := range leaderboard {
+			if entry.IsFriend {
+				n := graph.node(entry.ID, 1)
+				n.Username, n.Elo, n.Rank = entry.Username, entry.Elo, entry.Rank
+				graph.link(self.User.ID, entry.ID, EdgeFriend, 1)
+				friends = append(friends, entry.Username)
+			}
+		}
+	}
+
+	// 2. Breadth-first exploration of game histories.
+	frontier := append([]string(nil), seeds...)
+	for depth := 0; depth <= c.MaxDepth; depth++ {
+		if depth == 1 {
+			for _, username := range friends {
+				if !visited[strings.ToLower(username)] {
+					frontier = append(frontier, username)
+				}
+			}
+		}
+		if len(frontier) == 0 {
+			if depth == 0 {
+				continue // Only friends to crawl.
+			}
+			break
+		}
+		if c.MaxProfiles > 0 {
+			frontier = frontier[:min(len(frontier), c.MaxProfiles-attempted)]
+		}
+		for _, username := range frontier {
+			visited[strings.ToLower(username)] = true
+		}
+		attempted += len(frontier)
+
+		var discovered []string
+		for _, result := range c.client.GetPlayersInfo(ctx, frontier) {
+			if result.Err != nil {
+				continue
+			}
+			info := result.Info
+			c.resolver.ObservePlayer(info)
+			assembler.AddPlayer(info)
+
+			n := graph.node(info.User.ID, depth)
+			n.Username, n.Elo, n.Rank, n.Fetched = info.User.Username, info.User.Elo, info.User.Rank, true
+
+			for _, entry := range info.History {
+				for _, id := range entry.DeathReason.Responsible() {
+					graph.node(id, depth+1)
+					discovered = append(discovered, id)
+				}
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// The next level is every newly seen player whose username we know.
+		frontier = frontier[:0]
+		for _, id := range discovered {
+			username, ok := c.resolver.Username(id)
+			if !ok || visited[strings.ToLower(username)] {
+				continue
+			}
+			visited[strings.ToLower(username)] = true
+			graph.node(id, depth+1).Username = username
+			frontier = append(frontier, username)
+		}
+		if c.MaxProfiles > 0 && attempted >= c.MaxProfiles {
+			break
+		}
+	}
+
+	// 3. Co-play edges, one unit of weight per shared game.
+	for _, game := range assembler.Games() {
+		for i, a := range game.Participants {
+			for _, b := range game.Participants[i+1:] {
+				graph.link(a.UserID, b.UserID, EdgeCoPlayed, 1)
+			}
+		}
+	}
+	return graph, nil
+}
+
+// --- Export ---
+
+// WriteJSON writes the graph as a JSON object with "nodes" and "edges" arrays.
+func (g *SocialGraph) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(struct {
+		Nodes []GraphNode `json:"nodes"`
+		Edges []GraphEdge `json:"edges"`
+	}{g.Nodes(), g.Edges()})
+}
+
+// WriteDOT writes the graph in Graphviz DOT format, labelling nodes with usernames.
+func (g *SocialGraph) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("graph wolfy {\n")
+	for _, n := range g.Nodes() {
+		label := n.Username
+		if label == "" {
+			label = n.ID
+		}
+		fmt.Fprintf(&b, "  %s [label=%s];\n", strconv.Quote(n.ID), strconv.Quote(label))
+	}
+	for _, e := range g.Edges() {
+		style := "solid"
+		if e.Kind == EdgeFriend {
+			style = "bold"
+		}
+		fmt.Fprintf(&b, "  %s -- %s [label=%s, weight=%d, style=%s];\n",
+			strconv.Quote(e.From), strconv.Quote(e.To), strconv.Quote(string(e.Kind)), e.Weight, style)
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// graphML mirrors the subset of the GraphML schema used by WriteGraphML.
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLItem `xml:"node"`
+	Edges       []graphMLItem `xml:"edge"`
+}
+
+type graphMLItem struct {
+	ID     string        `xml:"id,attr,omitempty"`
+	Source string        `xml:"source,attr,omitempty"`
+	Target string        `xml:"target,attr,omitempty"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// WriteGraphML writes the graph in GraphML format, for tools such as Gephi or yEd.
+func (g *SocialGraph) WriteGraphML(w io.Writer) error {
+	doc := graphML{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "username", For: "node", AttrName: "username", AttrType: "string"},
+			{ID: "depth", For: "node", AttrName: "depth", AttrType: "int"},
+			{ID: "fetched", For: "node", AttrName: "fetched", AttrType: "boolean"},
+			{ID: "elo", For: "node", AttrName: "elo", AttrType: "int"},
+			{ID: "kind", For: "edge", AttrName: "kind", AttrType: "string"},
+			{ID: "weight", For: "edge", AttrName: "weight", AttrType: "int"},
+		},
+		Graph: graphMLGraph{ID: "wolfy", EdgeDefault: "undirected"},
+	}
+	for _, n := range g.Nodes() {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLItem{ID: n.ID, Data: []graphMLData{
+			{Key: "username", Value: n.Username},
+			{Key: "depth", Value: strconv.Itoa(n.Depth)},
+			{Key: "fetched", Value: strconv.FormatBool(n.Fetched)},
+			{Key: "elo", Value: strconv.Itoa(n.Elo)},
+		}})
+	}
+	for _, e := range g.Edges() {
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLItem{Source: e.From, Target: e.To, Data: []graphMLData{
+			{Key: "kind", Value: string(e.Kind)},
+			{Key: "weight", Value: strconv.Itoa(e.Weight)},
+		}})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
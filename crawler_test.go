@@ -0,0 +1,47 @@
+package wolfyclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestCrawlerMaxProfilesCountsFailures(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username := strings.TrimPrefix(r.URL.Path, "/leaderboard/player/")
+		requested = append(requested, username)
+		if username == "broken" {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(PlayerInfoResponse{
+			User: PlayerUser{ID: "1", Username: username},
+			History: []GameHistoryEntry{{
+				GameID:      "g1",
+				DeathReason: &DeathReason{Type: DeathVote, VotersIDs: []string{"2"}},
+			}},
+		})
+	}))
+	defer server.Close()
+	base, _ := url.Parse(server.URL + "/")
+	client := &Client{baseURL: base, httpClient: server.Client(), batchConcurrency: 1}
+
+	resolver := NewResolver(client, 0)
+	resolver.Observe("2", "bob")
+	crawler := NewCrawler(client, resolver)
+	crawler.MaxProfiles = 2
+
+	if _, err := crawler.Crawl(context.Background(), []string{"broken", "alice"}); err != nil {
+		t.Fatal(err)
+	}
+	// The failed lookup uses up the budget: bob, discovered through alice, is not fetched.
+	if got := strings.Join(requested, ","); got != "broken,alice" && got != "alice,broken" {
+		t.Errorf("requested %s, want broken and alice only", got)
+	}
+}
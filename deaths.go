@@ -0,0 +1,213 @@
+package wolfyclient
+
+// DeathType is the cause of a player's death, as found in DeathReason.Type.
+type DeathType string
+
+// Known death types.
+const (
+	DeathUnknown       DeathType = "unknown"
+	DeathVote          DeathType = "vote"          // Eliminated by the village vote; see VotersIDs.
+	DeathMayor         DeathType = "mayor"         // Eliminated by the mayor breaking a tie; see MayorID.
+	DeathWolves        DeathType = "wolves"        // Devoured by the werewolves at night.
+	DeathWitch         DeathType = "witch"         // Poisoned by the witch.
+	DeathHunter        DeathType = "hunter"        // Shot by a dying hunter; see HunterID.
+	DeathLover         DeathType = "lover"         // Died of grief after their lover; see LoverID.
+	DeathWhiteWerewolf DeathType = "whiteWerewolf" // Devoured by the white werewolf.
+	DeathPyromaniac    DeathType = "pyromaniac"    // Burned by the pyromaniac.
+	DeathTalkative     DeathType = "talkative"     // A talkative wolf who failed to say their word.
+	DeathInactivity    DeathType = "inactivity"    // Kicked out for being inactive.
+	DeathLeave         DeathType = "leave"         // Left the game.
+)
+
+// DeathTypes returns every known death type.
+func DeathTypes() []DeathType {
+	return []DeathType{
+		DeathVote, DeathMayor, DeathWolves, DeathWitch, DeathHunter, DeathLover,
+		DeathWhiteWerewolf, DeathPyromaniac, DeathTalkative, DeathInactivity, DeathLeave,
+	}
+}
+
+// Valid reports whether the death type is one known to this library.
+func (t DeathType) Valid() bool {
+	for _, known := range DeathTypes() {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Known returns the death type itself if it is valid, or DeathUnknown otherwise.
+func (t DeathType) Known() DeathType {
+	if !t.Valid() {
+		return DeathUnknown
+	}
+	return t
+}
+
+func (t DeathType) String() string { return string(t) }
+
+// MarshalText implements encoding.TextMarshaler.
+func (t DeathType) MarshalText() ([]byte, error) { return []byte(t), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler. Unknown values are kept as-is.
+func (t *DeathType) UnmarshalText(text []byte) error {
+	*t = DeathType(text)
+	return nil
+}
+
+// Responsible returns the IDs of the players responsible for the death.
+// It is empty for deaths that have no identified culprit, such as a night kill by the wolves.
+func (d *DeathReason) Responsible() []string {
+	if d == nil {
+		return nil
+	}
+
+	switch d.Type {
+	case DeathVote:
+		return append([]string(nil), d.VotersIDs...)
+	case DeathHunter:
+		return nonEmpty(d.HunterID)
+	case DeathMayor:
+		return nonEmpty(d.MayorID)
+	case DeathLover:
+		return nonEmpty(d.LoverID)
+	}
+
+	// Unknown death types: report whoever the server named.
+	responsible := append([]string(nil), d.VotersIDs...)
+	return append(responsible, nonEmpty(d.HunterID, d.MayorID, d.LoverID)...)
+}
+
+// nonEmpty returns the given IDs, skipping empty ones.
+func nonEmpty(ids ...string) []string {
+	var result []string
+	for _, id := range ids {
+		if id != "" {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// --- Analytics ---
+
+// DeathStats summarizes how a player died across a set of games.
+type DeathStats struct {
+	Games    int               // Number of games analyzed.
+	Deaths   int               // Number of games the player died in.
+	Survived int               // Number of games the player survived.
+	ByType   map[DeathType]int // Number of deaths per cause.
+	// AverageDeathDay is the mean day number of the player's deaths, 0 if they never died.
+	// It only covers the games the player died in; see AverageSurvivalDays for all games.
+	AverageDeathDay float64
+	// AverageSurvivalDays is the mean number of days the player stayed alive: the day of
+	// death for games they died in, the last day of the game for games they survived.
+	// It is only set by AnalyzeSurvival.
+	AverageSurvivalDays float64
+	// SurvivalGames is the number of games AverageSurvivalDays covers. Survived games whose
+	// last day is unknown are left out.
+	SurvivalGames int
+}
+
+// DeathRate returns the share of games, between 0 and 1, in which the player died.
+func (s DeathStats) DeathRate() float64 {
+	if s.Games == 0 {
+		return 0
+	}
+	return float64(s.Deaths) / float64(s.Games)
+}
+
+// Share returns the share of deaths, between 0 and 1, due to the given cause.
+func (s DeathStats) Share(t DeathType) float64 {
+	if s.Deaths == 0 {
+		return 0
+	}
+	return float64(s.ByType[t]) / float64(s.Deaths)
+}
+
+// AnalyzeDeaths computes the death cause distribution, the number of games survived and the
+// average day of death over a history.
+func AnalyzeDeaths(history []GameHistoryEntry) DeathStats {
+	return AnalyzeSurvival(history, nil)
+}
+
+// AnalyzeSurvival is like AnalyzeDeaths, and also computes the average number of days
+// survived. A history entry does not tell how long a survived game lasted, so lastDay
+// provides it, e.g. GameAssembler.LastDay once the histories of other players of those
+// games have been added. With a nil lastDay, the survival fields are left unset.
+func AnalyzeSurvival(history []GameHistoryEntry, lastDay func(gameID string) (int, bool)) DeathStats {
+	stats := DeathStats{ByType: make(map[DeathType]int)}
+	deathDays, survivalDays := 0, 0
+	for _, entry := range history {
+		stats.Games++
+		if entry.DeathReason == nil {
+			stats.Survived++
+			if lastDay == nil {
+				continue
+			}
+			if day, ok := lastDay(entry.GameID); ok {
+				survivalDays += day
+				stats.SurvivalGames++
+			}
+			continue
+		}
+		stats.Deaths++
+		stats.ByType[entry.DeathReason.Type]++
+		deathDays += entry.DeathReason.DayNumber
+		survivalDays += entry.DeathReason.DayNumber
+		stats.SurvivalGames++
+	}
+	if stats.Deaths > 0 {
+		stats.AverageDeathDay = float64(deathDays) / float64(stats.Deaths)
+	}
+	if lastDay == nil {
+		stats.SurvivalGames = 0
+	} else if stats.SurvivalGames > 0 {
+		stats.AverageSurvivalDays = float64(survivalDays) / float64(stats.SurvivalGames)
+	}
+	return stats
+}
+
+// VoteOutStats reports how often a player was voted out with the help of a set of users.
+type VoteOutStats struct {
+	VotedOut int            // Number of games the player was eliminated by a vote.
+	ByAny    int            // Vote eliminations in which at least one of the users voted.
+	ByAll    int            // Vote eliminations in which every one of the users voted.
+	ByVoter  map[string]int // Vote eliminations each user took part in.
+}
+
+// VotedOutBy counts, over a player's history, the vote eliminations the given users took part in.
+func VotedOutBy(history []GameHistoryEntry, userIDs ...string) VoteOutStats {
+	stats := VoteOutStats{ByVoter: make(map[string]int, len(userIDs))}
+	for _, id := range userIDs {
+		stats.ByVoter[id] = 0
+	}
+
+	for _, entry := range history {
+		if entry.DeathReason == nil || entry.DeathReason.Type != DeathVote {
+			continue
+		}
+		stats.VotedOut++
+
+		voters := make(map[string]bool, len(entry.DeathReason.VotersIDs))
+		for _, voter := range entry.DeathReason.VotersIDs {
+			voters[voter] = true
+		}
+
+		matched := 0
+		for _, id := range userIDs {
+			if voters[id] {
+				stats.ByVoter[id]++
+				matched++
+			}
+		}
+		if matched > 0 {
+			stats.ByAny++
+		}
+		if len(userIDs) > 0 && matched == len(userIDs) {
+			stats.ByAll++
+		}
+	}
+	return stats
+}
@@ -0,0 +1,34 @@
+package wolfyclient
+
+import "testing"
+
+func TestAnalyzeSurvival(t *testing.T) {
+	died := func(userID, gameID string, day int) GameHistoryEntry {
+		return GameHistoryEntry{UserID: userID, GameID: gameID, DeathReason: &DeathReason{Type: DeathWolves, DayNumber: day}}
+	}
+	history := []GameHistoryEntry{
+		died("me", "g1", 2),
+		{UserID: "me", GameID: "g2"}, // Survived; others died up to day 5.
+		{UserID: "me", GameID: "g3"}, // Survived; nobody else seen.
+	}
+
+	assembler := NewGameAssembler()
+	assembler.Add(history...)
+	assembler.Add(died("a", "g2", 1), died("b", "g2", 5), died("c", "g1", 3))
+
+	stats := AnalyzeSurvival(history, assembler.LastDay)
+	if stats.Games != 3 || stats.Deaths != 1 || stats.Survived != 2 {
+		t.Errorf("games/deaths/survived = %d/%d/%d, want 3/1/2", stats.Games, stats.Deaths, stats.Survived)
+	}
+	if stats.AverageDeathDay != 2 {
+		t.Errorf("AverageDeathDay = %v, want 2", stats.AverageDeathDay)
+	}
+	// Day 2 for the death, day 5 for g2; g3 has no known length.
+	if stats.SurvivalGames != 2 || stats.AverageSurvivalDays != 3.5 {
+		t.Errorf("survival = %v over %d games, want 3.5 over 2", stats.AverageSurvivalDays, stats.SurvivalGames)
+	}
+
+	if stats := AnalyzeDeaths(history); stats.AverageSurvivalDays != 0 || stats.SurvivalGames != 0 {
+		t.Errorf("AnalyzeDeaths set survival to %v over %d games", stats.AverageSurvivalDays, stats.SurvivalGames)
+	}
+}
@@ -0,0 +1,254 @@
+package wolfyclient
+
+// The enum types below are string-based so that values unknown to this library
+// survive a JSON round trip untouched. Use Valid to detect them, and Known to
+// collapse them to the Unknown constant when a closed set of values is needed.
+
+// Rarity is the rarity tier of a cosmetic item or pack.
+type Rarity string
+
+// Known rarities, from the most to the least common.
+const (
+	RarityUnknown   Rarity = "unknown"
+	RarityCommon    Rarity = "common"
+	RarityRare      Rarity = "rare"
+	RarityEpic      Rarity = "epic"
+	RarityLegendary Rarity = "legendary"
+)
+
+// rarityTiers orders the known rarities; unknown values have tier 0.
+var rarityTiers = map[Rarity]int{
+	RarityCommon:    1,
+	RarityRare:      2,
+	RarityEpic:      3,
+	RarityLegendary: 4,
+}
+
+// Rarities returns every known rarity, from the most to the least common.
+func Rarities() []Rarity {
+	return []Rarity{RarityCommon, RarityRare, RarityEpic, RarityLegendary}
+}
+
+// Valid reports whether the rarity is one known to this library.
+func (r Rarity) Valid() bool {
+	_, ok := rarityTiers[r]
+	return ok
+}
+
+// Known returns the rarity itself if it is valid, or RarityUnknown otherwise.
+func (r Rarity) Known() Rarity {
+	if !r.Valid() {
+		return RarityUnknown
+	}
+	return r
+}
+
+// Tier returns the position of the rarity in the rarity ladder, starting at 1 for common.
+// Unknown rarities have tier 0 and sort before every known one.
+func (r Rarity) Tier() int {
+	return rarityTiers[r]
+}
+
+// Less reports whether r is a lower tier than other.
+func (r Rarity) Less(other Rarity) bool {
+	return r.Tier() < other.Tier()
+}
+
+func (r Rarity) String() string { return string(r) }
+
+// MarshalText implements encoding.TextMarshaler.
+func (r Rarity) MarshalText() ([]byte, error) { return []byte(r), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler. Unknown values are kept as-is.
+func (r *Rarity) UnmarshalText(text []byte) error {
+	*r = Rarity(text)
+	return nil
+}
+
+// Currency is the in-game currency an item, pack or slot is priced in.
+// Real-money offers (subscriptions, moon packs) keep a plain ISO currency string.
+type Currency string
+
+// Known in-game currencies.
+const (
+	CurrencyUnknown Currency = "unknown"
+	CurrencyCoins   Currency = "coins"
+	CurrencyMoons   Currency = "moons"
+)
+
+// Currencies returns every known in-game currency.
+func Currencies() []Currency {
+	return []Currency{CurrencyCoins, CurrencyMoons}
+}
+
+// Valid reports whether the currency is one known to this library.
+func (c Currency) Valid() bool {
+	return c == CurrencyCoins || c == CurrencyMoons
+}
+
+// Known returns the currency itself if it is valid, or CurrencyUnknown otherwise.
+func (c Currency) Known() Currency {
+	if !c.Valid() {
+		return CurrencyUnknown
+	}
+	return c
+}
+
+func (c Currency) String() string { return string(c) }
+
+// MarshalText implements encoding.TextMarshaler.
+func (c Currency) MarshalText() ([]byte, error) { return []byte(c), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler. Unknown values are kept as-is.
+func (c *Currency) UnmarshalText(text []byte) error {
+	*c = Currency(text)
+	return nil
+}
+
+// Access describes how a cosmetic item can be obtained.
+type Access string
+
+// Known access modes.
+const (
+	AccessUnknown Access = "unknown"
+	AccessFree    Access = "free"  // Available to everyone.
+	AccessShop    Access = "shop"  // Bought in the shop with coins or moons.
+	AccessLevel   Access = "level" // Unlocked by reaching the item's level.
+	AccessAlpha   Access = "alpha" // Reserved to Alpha subscribers.
+	AccessDrop    Access = "drop"  // Only obtainable through a drop pack.
+	AccessEvent   Access = "event" // Handed out during a limited event.
+)
+
+// AccessModes returns every known access mode.
+func AccessModes() []Access {
+	return []Access{AccessFree, AccessShop, AccessLevel, AccessAlpha, AccessDrop, AccessEvent}
+}
+
+// Valid reports whether the access mode is one known to this library.
+func (a Access) Valid() bool {
+	for _, known := range AccessModes() {
+		if a == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Known returns the access mode itself if it is valid, or AccessUnknown otherwise.
+func (a Access) Known() Access {
+	if !a.Valid() {
+		return AccessUnknown
+	}
+	return a
+}
+
+func (a Access) String() string { return string(a) }
+
+// MarshalText implements encoding.TextMarshaler.
+func (a Access) MarshalText() ([]byte, error) { return []byte(a), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler. Unknown values are kept as-is.
+func (a *Access) UnmarshalText(text []byte) error {
+	*a = Access(text)
+	return nil
+}
+
+// SkinPartType is the slot of the character a cosmetic item is worn on.
+// Its values match the keys used by UpdateSkinSlot and Skin.Parts.
+type SkinPartType string
+
+// Known skin part types, in the order of the Skin struct.
+const (
+	SkinPartUnknown   SkinPartType = "unknown"
+	SkinPartEyes      SkinPartType = "eyes"
+	SkinPartFace      SkinPartType = "face"
+	SkinPartHair      SkinPartType = "hair"
+	SkinPartNose      SkinPartType = "nose"
+	SkinPartTop       SkinPartType = "top"
+	SkinPartBottom    SkinPartType = "bottom"
+	SkinPartShoes     SkinPartType = "shoes"
+	SkinPartTombstone SkinPartType = "tombstone"
+	SkinPartGlasses   SkinPartType = "glasses"
+)
+
+// SkinPartTypes returns every known skin part type, in the order of the Skin struct.
+func SkinPartTypes() []SkinPartType {
+	return []SkinPartType{
+		SkinPartEyes, SkinPartFace, SkinPartHair, SkinPartNose, SkinPartTop,
+		SkinPartBottom, SkinPartShoes, SkinPartTombstone, SkinPartGlasses,
+	}
+}
+
+// Valid reports whether the part type is one known to this library.
+func (t SkinPartType) Valid() bool {
+	for _, known := range SkinPartTypes() {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Known returns the part type itself if it is valid, or SkinPartUnknown otherwise.
+func (t SkinPartType) Known() SkinPartType {
+	if !t.Valid() {
+		return SkinPartUnknown
+	}
+	return t
+}
+
+func (t SkinPartType) String() string { return string(t) }
+
+// MarshalText implements encoding.TextMarshaler.
+func (t SkinPartType) MarshalText() ([]byte, error) { return []byte(t), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler. Unknown values are kept as-is.
+func (t *SkinPartType) UnmarshalText(text []byte) error {
+	*t = SkinPartType(text)
+	return nil
+}
+
+// FriendsVisibility is who can see a user's friend list.
+type FriendsVisibility string
+
+// Known friend list visibilities.
+const (
+	FriendsVisibilityUnknown  FriendsVisibility = "unknown"
+	FriendsVisibilityEveryone FriendsVisibility = "everyone" // Any player.
+	FriendsVisibilityFriends  FriendsVisibility = "friends"  // The user's friends only.
+	FriendsVisibilityNobody   FriendsVisibility = "nobody"   // Hidden from everyone else.
+)
+
+// FriendsVisibilities returns every known friend list visibility.
+func FriendsVisibilities() []FriendsVisibility {
+	return []FriendsVisibility{FriendsVisibilityEveryone, FriendsVisibilityFriends, FriendsVisibilityNobody}
+}
+
+// Valid reports whether the visibility is one known to this library.
+func (v FriendsVisibility) Valid() bool {
+	for _, known := range FriendsVisibilities() {
+		if v == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Known returns the visibility itself if it is valid, or FriendsVisibilityUnknown otherwise.
+func (v FriendsVisibility) Known() FriendsVisibility {
+	if !v.Valid() {
+		return FriendsVisibilityUnknown
+	}
+	return v
+}
+
+func (v FriendsVisibility) String() string { return string(v) }
+
+// MarshalText implements encoding.TextMarshaler.
+func (v FriendsVisibility) MarshalText() ([]byte, error) { return []byte(v), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler. Unknown values are kept as-is.
+func (v *FriendsVisibility) UnmarshalText(text []byte) error {
+	*v = FriendsVisibility(text)
+	return nil
+}
@@ -0,0 +1,156 @@
+package wolfyclient
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// onlineWindow is how recent a friend's last game must be for them to be considered online.
+const onlineWindow = 30 * time.Minute
+
+// Friend is a friend of the authenticated user, enriched with their leaderboard summary.
+type Friend struct {
+	ID          string
+	Username    string
+	Rank        int
+	XP          int
+	Elo         int
+	GamePlayed  int
+	SlotID      string
+	SkinVersion string
+	// Resolved is false when the friend could not be found in the friend leaderboard,
+	// in which case only ID is set.
+	Resolved bool
+	// LastPlayed is the start of the friend's most recent game.
+	// It is only set by FriendsWithActivity, and stays zero for friends without any game.
+	LastPlayed time.Time
+}
+
+// Online reports whether the friend is probably online, i.e. started a game recently.
+// Wolfy does not expose presence, so this is inferred from LastPlayed.
+func (f Friend) Online() bool {
+	return !f.LastPlayed.IsZero() && time.Since(f.LastPlayed) < onlineWindow
+}
+
+// Friends returns the authenticated user's friends, with the username, rank and Elo of each,
+// sorted by username. It joins GetFriendList with GetFriendLeaderboard.
+func (c *Client) Friends() ([]Friend, error) {
+	ids, err := c.GetFriendList()
+	if err != nil {
+		return nil, err
+	}
+	leaderboard, err := c.GetFriendLeaderboard()
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]LeaderboardEntry, len(leaderboard))
+	for _, entry := range leaderboard {
+		byID[entry.ID] = entry
+	}
+
+	friends := make([]Friend, 0, len(ids))
+	for _, id := range ids {
+		entry, ok := byID[id]
+		if !ok {
+			friends = append(friends, Friend{ID: id})
+			continue
+		}
+		friends = append(friends, Friend{
+			ID:          id,
+			Username:    entry.Username,
+			Rank:        entry.Rank,
+			XP:          entry.XP,
+			Elo:         entry.Elo,
+			GamePlayed:  entry.GamePlayed,
+			SlotID:      entry.SlotID,
+			SkinVersion: entry.SkinVersion,
+			Resolved:    true,
+		})
+	}
+
+	sort.SliceStable(friends, func(i, j int) bool { return friends[i].Username < friends[j].Username })
+	return friends, nil
+}
+
+// FriendsWithActivity is like Friends, but also fetches every resolved friend's profile
+// to fill in LastPlayed from their game history. Profiles are fetched with GetPlayersInfo,
+// so the client's rate limit and batch concurrency apply. Friends whose profile could not
+// be fetched are returned without LastPlayed.
+func (c *Client) FriendsWithActivity(ctx context.Context) ([]Friend, error) {
+	friends, err := c.Friends()
+	if err != nil {
+		return nil, err
+	}
+
+	var usernames []string
+	for _, f := range friends {
+		if f.Resolved {
+			usernames = append(usernames, f.Username)
+		}
+	}
+
+	lastPlayed := make(map[string]time.Time, len(usernames))
+	for _, result := range c.GetPlayersInfo(ctx, usernames) {
+		if result.Err != nil {
+			continue
+		}
+		for _, entry := range result.Info.History {
+			if entry.CreatedAt.After(lastPlayed[result.Info.User.ID]) {
+				lastPlayed[result.Info.User.ID] = entry.CreatedAt.Time
+			}
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range friends {
+		friends[i].LastPlayed = lastPlayed[friends[i].ID]
+	}
+	return friends, nil
+}
+
+// CompleteFriends fills in friends that Friends could not resolve, looking their username up
+// by ID in the resolver's cache and then fetching their profile. Friends the resolver has never
+// seen stay unresolved, since the API has no lookup by ID.
+func (r *Resolver) CompleteFriends(ctx context.Context, friends []Friend) []Friend {
+	var usernames []string
+	for _, f := range friends {
+		if f.Resolved {
+			r.Observe(f.ID, f.Username)
+		} else if username, ok := r.Username(f.ID); ok {
+			usernames = append(usernames, username)
+		}
+	}
+
+	profiles := make(map[string]PlayerUser, len(usernames))
+	for _, result := range r.client.GetPlayersInfo(ctx, usernames) {
+		if result.Err == nil {
+			r.ObservePlayer(result.Info)
+			profiles[result.Info.User.ID] = result.Info.User
+		}
+	}
+
+	completed := append([]Friend(nil), friends...)
+	for i, f := range completed {
+		user, ok := profiles[f.ID]
+		if f.Resolved || !ok {
+			continue
+		}
+		completed[i] = Friend{
+			ID:          user.ID,
+			Username:    user.Username,
+			Rank:        user.Rank,
+			XP:          user.XP,
+			Elo:         user.Elo,
+			GamePlayed:  user.GamePlayed,
+			SlotID:      user.SlotID,
+			SkinVersion: user.SkinVersion,
+			Resolved:    true,
+			LastPlayed:  f.LastPlayed,
+		}
+	}
+	return completed
+}
@@ -0,0 +1,273 @@
+package wolfyclient
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// rosterIDPrefix marks roster entries that are user IDs rather than usernames.
+const rosterIDPrefix = "id:"
+
+// errMissingRosterID is reported for an "id:" roster entry without a user ID.
+var errMissingRosterID = errors.New("missing user ID after '" + rosterIDPrefix + "'")
+
+// rosterID returns the user ID of an "id:<user ID>" roster entry.
+// ok is false for username entries; id is empty for a bare "id:".
+func rosterID(entry string) (id string, ok bool) {
+	id, ok = strings.CutPrefix(entry, rosterIDPrefix)
+	return strings.TrimSpace(id), ok
+}
+
+// ParseRoster reads a roster file: one username per line, or "id:<user ID>" for entries
+// given by ID. Blank lines and lines starting with '#' are ignored.
+func ParseRoster(r io.Reader) ([]string, error) {
+	var roster []string
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if id, ok := rosterID(line); ok && id == "" {
+			return nil, fmt.Errorf("roster line %d: %w", n, errMissingRosterID)
+		}
+		roster = append(roster, line)
+	}
+	return roster, scanner.Err()
+}
+
+// FriendSyncAction is the kind of change a sync step makes to the friend list.
+type FriendSyncAction string
+
+const (
+	FriendSyncAdd    FriendSyncAction = "add"
+	FriendSyncRemove FriendSyncAction = "remove"
+)
+
+// FriendSyncStep is a single change of a friend sync plan.
+type FriendSyncStep struct {
+	Action   FriendSyncAction
+	UserID   string
+	Username string // Empty if the user's name is unknown.
+}
+
+func (s FriendSyncStep) String() string {
+	name := s.Username
+	if name == "" {
+		name = rosterIDPrefix + s.UserID
+	}
+	if s.Action == FriendSyncAdd {
+		return "+ " + name
+	}
+	return "- " + name
+}
+
+// UnresolvedRosterEntry is a roster entry whose user ID could not be found.
+type UnresolvedRosterEntry struct {
+	Entry string
+	Err   error
+}
+
+// FriendSyncPlan lists the changes needed to make the friend list match a roster.
+type FriendSyncPlan struct {
+	Add        []FriendSyncStep
+	Remove     []FriendSyncStep
+	Keep       []string // IDs of friends left as they are: in the roster, or possibly an unresolved entry.
+	Unresolved []UnresolvedRosterEntry
+}
+
+// Steps returns every step of the plan, additions first.
+func (p *FriendSyncPlan) Steps() []FriendSyncStep {
+	return append(append([]FriendSyncStep(nil), p.Add...), p.Remove...)
+}
+
+// String renders the plan in a human readable form, one step per line.
+func (p *FriendSyncPlan) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d to add, %d to remove, %d unchanged\n", len(p.Add), len(p.Remove), len(p.Keep))
+	for _, step := range p.Steps() {
+		fmt.Fprintln(&b, step)
+	}
+	for _, u := range p.Unresolved {
+		fmt.Fprintf(&b, "? %s: %v\n", u.Entry, u.Err)
+	}
+	return b.String()
+}
+
+// PlanFriendSync compares the friend list with a roster of usernames and "id:" entries,
+// as read by ParseRoster, and returns the changes needed to make them match.
+// Usernames are resolved through the resolver one at a time, or, if it is nil, with a single
+// GetPlayersInfo batch; either way, lookups respect the client's rate limit (see SetRateLimit).
+// Entries that cannot be resolved are reported in the plan and never cause removals: a friend
+// is kept when their username matches an unresolved entry, or when their username is unknown
+// while some entries are unresolved.
+func (c *Client) PlanFriendSync(roster []string, resolver *Resolver) (*FriendSyncPlan, error) {
+	current, err := c.GetFriendList()
+	if err != nil {
+		return nil, err
+	}
+
+	// Usernames of current friends, for readable removal steps.
+	names := make(map[string]string)
+	if leaderboard, err := c.GetFriendLeaderboard(); err == nil {
+		for _, entry := range leaderboard {
+			names[entry.ID] = entry.Username
+		}
+		if resolver != nil {
+			resolver.ObserveLeaderboard(leaderboard)
+		}
+	}
+
+	plan := &FriendSyncPlan{}
+	desired := make(map[string]string)  // ID -> username
+	unresolved := make(map[string]bool) // lowercased usernames that could not be resolved
+	var usernames []string
+	for _, entry := range roster {
+		id, ok := rosterID(entry)
+		switch {
+		case !ok:
+			usernames = append(usernames, entry)
+		case id == "":
+			plan.Unresolved = append(plan.Unresolved, UnresolvedRosterEntry{Entry: entry, Err: errMissingRosterID})
+		default:
+			desired[id] = names[id]
+		}
+	}
+
+	resolved := c.resolveRoster(usernames, resolver)
+	for i, username := range usernames {
+		if err := resolved[i].Err; err != nil {
+			plan.Unresolved = append(plan.Unresolved, UnresolvedRosterEntry{Entry: username, Err: err})
+			unresolved[strings.ToLower(username)] = true
+			continue
+		}
+		desired[resolved[i].ID] = username
+	}
+
+	// A current friend may be an unresolved roster entry, e.g. after a transient error:
+	// keep friends named like one, and friends whose name is unknown, rather than remove them.
+	mayBeUnresolved := func(id string) bool {
+		if len(unresolved) == 0 {
+			return false
+		}
+		name, ok := names[id]
+		return !ok || name == "" || unresolved[strings.ToLower(name)]
+	}
+
+	isFriend := make(map[string]bool, len(current))
+	for _, id := range current {
+		isFriend[id] = true
+		if _, ok := desired[id]; ok || mayBeUnresolved(id) {
+			plan.Keep = append(plan.Keep, id)
+		} else {
+			plan.Remove = append(plan.Remove, FriendSyncStep{Action: FriendSyncRemove, UserID: id, Username: names[id]})
+		}
+	}
+	for id, username := range desired {
+		if !isFriend[id] {
+			plan.Add = append(plan.Add, FriendSyncStep{Action: FriendSyncAdd, UserID: id, Username: username})
+		}
+	}
+
+	byName := func(steps []FriendSyncStep) {
+		sort.Slice(steps, func(i, j int) bool { return steps[i].Username+steps[i].UserID < steps[j].Username+steps[j].UserID })
+	}
+	byName(plan.Add)
+	byName(plan.Remove)
+	sort.Strings(plan.Keep)
+	return plan, nil
+}
+
+// resolvedRosterEntry is the user ID a roster username resolved to, or the error that prevented it.
+type resolvedRosterEntry struct {
+	ID  string
+	Err error
+}
+
+// resolveRoster resolves roster usernames to user IDs, in the same order.
+func (c *Client) resolveRoster(usernames []string, resolver *Resolver) []resolvedRosterEntry {
+	resolved := make([]resolvedRosterEntry, len(usernames))
+	if resolver != nil {
+		for i, username := range usernames {
+			resolved[i].ID, resolved[i].Err = resolver.ResolveID(username)
+		}
+		return resolved
+	}
+
+	for i, result := range c.GetPlayersInfo(context.Background(), usernames) {
+		if result.Err != nil {
+			resolved[i].Err = fmt.Errorf("could not find user '%s': %w", result.Username, result.Err)
+			continue
+		}
+		resolved[i].ID = result.Info.User.ID
+	}
+	return resolved
+}
+
+// FriendSyncOutcome is the result of applying one step of a plan.
+type FriendSyncOutcome struct {
+	Step    FriendSyncStep
+	Applied bool   // False for dry runs, failed steps and steps skipped after cancellation.
+	Message string // The server's response message, if any.
+	Err     error
+}
+
+// ApplyFriendSync applies a plan, waiting interval between consecutive mutations so as not
+// to hammer the server. With dryRun set, nothing is sent and every step is reported as not applied.
+// A failing step does not stop the others; cancelling ctx skips the remaining steps.
+func (c *Client) ApplyFriendSync(ctx context.Context, plan *FriendSyncPlan, interval time.Duration, dryRun bool) []FriendSyncOutcome {
+	steps := plan.Steps()
+	outcomes := make([]FriendSyncOutcome, len(steps))
+
+	for i, step := range steps {
+		outcomes[i].Step = step
+		if dryRun {
+			outcomes[i].Message = "dry run"
+			continue
+		}
+
+		if i > 0 && interval > 0 {
+			timer := time.NewTimer(interval)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			outcomes[i].Err = err
+			continue
+		}
+
+		var resp *MessageResponse
+		var err error
+		if step.Action == FriendSyncAdd {
+			resp, err = c.AddFriend(step.UserID)
+		} else {
+			resp, err = c.RemoveFriend(step.UserID)
+		}
+		if err != nil {
+			outcomes[i].Err = fmt.Errorf("could not %s '%s': %w", step.Action, step.UserID, err)
+			continue
+		}
+		outcomes[i].Applied = true
+		outcomes[i].Message = resp.Message
+	}
+	return outcomes
+}
+
+func (o FriendSyncOutcome) String() string {
+	switch {
+	case o.Err != nil:
+		return fmt.Sprintf("%s: failed: %v", o.Step, o.Err)
+	case o.Applied:
+		return fmt.Sprintf("%s: done", o.Step)
+	}
+	return fmt.Sprintf("%s: %s", o.Step, o.Message)
+}
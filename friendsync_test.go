@@ -0,0 +1,167 @@
+package wolfyclient
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// newFakeSocialServer serves a friend list, its leaderboard and player profiles.
+// Profiles of usernames listed in failing answer 503.
+func newFakeSocialServer(t *testing.T, friends []LeaderboardEntry, players map[string]string, failing ...string) *Client {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	writeJSON := func(w http.ResponseWriter, v any) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(v)
+	}
+	mux.HandleFunc("/social/friends", func(w http.ResponseWriter, r *http.Request) {
+		ids := make([]string, len(friends))
+		for i, f := range friends {
+			ids[i] = f.ID
+		}
+		writeJSON(w, ids)
+	})
+	mux.HandleFunc("/leaderboard", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, friends)
+	})
+	mux.HandleFunc("/leaderboard/player/", func(w http.ResponseWriter, r *http.Request) {
+		username := strings.TrimPrefix(r.URL.Path, "/leaderboard/player/")
+		for _, f := range failing {
+			if f == username {
+				http.Error(w, "unavailable", http.StatusServiceUnavailable)
+				return
+			}
+		}
+		id, ok := players[username]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		writeJSON(w, PlayerInfoResponse{User: PlayerUser{ID: id, Username: username}})
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	base, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Client{baseURL: base, httpClient: server.Client()}
+}
+
+func TestPlanFriendSync(t *testing.T) {
+	friends := []LeaderboardEntry{
+		{ID: "1", Username: "alice", IsFriend: true},
+		{ID: "2", Username: "bob", IsFriend: true},
+		{ID: "3", Username: "carol", IsFriend: true},
+	}
+	players := map[string]string{"alice": "1", "bob": "2", "carol": "3", "dave": "4"}
+	client := newFakeSocialServer(t, friends, players)
+
+	plan, err := client.PlanFriendSync([]string{"alice", "bob", "dave"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := stepIDs(plan.Add); got != "4" {
+		t.Errorf("Add = %s, want 4", got)
+	}
+	if got := stepIDs(plan.Remove); got != "3" {
+		t.Errorf("Remove = %s, want 3", got)
+	}
+	if got := strings.Join(plan.Keep, ","); got != "1,2" {
+		t.Errorf("Keep = %s, want 1,2", got)
+	}
+}
+
+func TestPlanFriendSyncUnresolvedNeverRemoves(t *testing.T) {
+	friends := []LeaderboardEntry{
+		{ID: "1", Username: "alice", IsFriend: true},
+		{ID: "2", Username: "bob", IsFriend: true},
+		{ID: "3", Username: "carol", IsFriend: true},
+	}
+	players := map[string]string{"alice": "1", "bob": "2", "carol": "3"}
+	client := newFakeSocialServer(t, friends, players, "bob")
+
+	plan, err := client.PlanFriendSync([]string{"alice", "bob"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Unresolved) != 1 || plan.Unresolved[0].Entry != "bob" || IsNotFound(plan.Unresolved[0].Err) {
+		t.Fatalf("Unresolved = %v, want bob failing with 503", plan.Unresolved)
+	}
+	for _, step := range plan.Remove {
+		if step.UserID == "2" {
+			t.Errorf("plan removes unresolved roster member bob:\n%s", plan)
+		}
+	}
+	if got := stepIDs(plan.Remove); got != "3" {
+		t.Errorf("Remove = %s, want 3", got)
+	}
+	if got := strings.Join(plan.Keep, ","); got != "1,2" {
+		t.Errorf("Keep = %s, want 1,2", got)
+	}
+}
+
+func TestPlanFriendSyncUnknownUser(t *testing.T) {
+	friends := []LeaderboardEntry{
+		{ID: "1", Username: "alice", IsFriend: true},
+		{ID: "2", Username: "bob", IsFriend: true},
+	}
+	players := map[string]string{"alice": "1", "bob": "2"}
+	client := newFakeSocialServer(t, friends, players)
+
+	plan, err := client.PlanFriendSync([]string{"alice", "zed", "id:"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Unresolved) != 2 {
+		t.Fatalf("Unresolved = %v, want zed and id:", plan.Unresolved)
+	}
+	for _, u := range plan.Unresolved {
+		switch u.Entry {
+		case "zed":
+			if !IsNotFound(u.Err) {
+				t.Errorf("zed error = %v, want a 404", u.Err)
+			}
+		case "id:":
+			if !errors.Is(u.Err, errMissingRosterID) {
+				t.Errorf("id: error = %v, want errMissingRosterID", u.Err)
+			}
+		default:
+			t.Errorf("unexpected unresolved entry %q", u.Entry)
+		}
+	}
+	// Unresolved entries only protect friends they may name; bob is named and not in the roster.
+	if got := stepIDs(plan.Remove); got != "2" {
+		t.Errorf("Remove = %s, want 2", got)
+	}
+}
+
+func TestParseRoster(t *testing.T) {
+	roster, err := ParseRoster(strings.NewReader("# friends\nalice\n\n id:42 \n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(roster, ","); got != "alice,id:42" {
+		t.Errorf("roster = %s, want alice,id:42", got)
+	}
+
+	if _, err := ParseRoster(strings.NewReader("alice\nid:\n")); !errors.Is(err, errMissingRosterID) {
+		t.Errorf("bare id: error = %v, want errMissingRosterID", err)
+	}
+}
+
+func stepIDs(steps []FriendSyncStep) string {
+	ids := make([]string, len(steps))
+	for i, step := range steps {
+		ids[i] = step.UserID
+	}
+	return strings.Join(ids, ",")
+}
@@ -0,0 +1,252 @@
+package wolfyclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// HistoryIterator pages through a player's game history, newest games first.
+//
+// The player endpoint only returns a short window of recent games. The iterator asks for
+// further pages with an "offset" query parameter; if the server ignores it and returns
+// games that were already seen, iteration stops there, so it is safe to use either way.
+//
+//	it := client.PlayerHistory("username")
+//	for it.Next() {
+//		for _, entry := range it.Page() { ... }
+//	}
+//	if err := it.Err(); err != nil { ... }
+type HistoryIterator struct {
+	client   *Client
+	username string
+	offset   int
+	seen     map[string]bool
+	page     []GameHistoryEntry
+	done     bool
+	err      error
+}
+
+// PlayerHistory returns an iterator over the game history of the player with the given username.
+func (c *Client) PlayerHistory(username string) *HistoryIterator {
+	return &HistoryIterator{
+		client:   c,
+		username: username,
+		seen:     make(map[string]bool),
+	}
+}
+
+// Next fetches the next page of history. It returns false when there are no more
+// games or an error occurred; check Err to tell the two apart.
+func (it *HistoryIterator) Next() bool {
+	if it.done {
+		return false
+	}
+
+	path := fmt.Sprintf("/leaderboard/player/%s", it.username)
+	if it.offset > 0 {
+		path += "?offset=" + strconv.Itoa(it.offset)
+	}
+	req, err := it.client.newRequest("GET", path, nil)
+	if err != nil {
+		it.err, it.done = err, true
+		return false
+	}
+
+	var playerInfo PlayerInfoResponse
+	if err := it.client.do(req, &playerInfo); err != nil {
+		it.err, it.done = err, true
+		return false
+	}
+	if it.offset == 0 {
+		it.client.observePlayer(&playerInfo)
+	}
+
+	// Keep only the games we have not returned yet. A page without any new game means
+	// we reached the end, or that the server does not support offsets.
+	it.page = it.page[:0]
+	for _, entry := range playerInfo.History {
+		if !it.seen[entry.GameID] {
+			it.seen[entry.GameID] = true
+			it.page = append(it.page, entry)
+		}
+	}
+	if len(it.page) == 0 {
+		it.done = true
+		return false
+	}
+
+	it.offset += len(playerInfo.History)
+	return true
+}
+
+// Page returns the games fetched by the last call to Next.
+// The slice is reused by the following call to Next.
+func (it *HistoryIterator) Page() []GameHistoryEntry {
+	return it.page
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *HistoryIterator) Err() error {
+	return it.err
+}
+
+// GetFullHistory fetches every game of a player's history that the server is willing to return.
+func (c *Client) GetFullHistory(username string) ([]GameHistoryEntry, error) {
+	var history []GameHistoryEntry
+	it := c.PlayerHistory(username)
+	for it.Next() {
+		history = append(history, it.Page()...)
+	}
+	if err := it.Err(); err != nil {
+		return nil, fmt.Errorf("could not fetch history of '%s': %w", username, err)
+	}
+	return history, nil
+}
+
+// --- Incremental Store ---
+
+// HistoryStore accumulates game history entries across polls, so that a player's complete
+// record builds up over time even though each request only returns recent games.
+// Entries are deduplicated by user and game ID. It is safe for concurrent use.
+type HistoryStore struct {
+	mu      sync.Mutex
+	path    string
+	entries map[string]map[string]GameHistoryEntry // userID -> gameID -> entry
+}
+
+// NewHistoryStore creates an in-memory history store.
+func NewHistoryStore() *HistoryStore {
+	return &HistoryStore{entries: make(map[string]map[string]GameHistoryEntry)}
+}
+
+// OpenHistoryStore loads a history store from a JSON file, or creates an empty one if the
+// file does not exist yet. Call Save to write it back.
+func OpenHistoryStore(path string) (*HistoryStore, error) {
+	store := NewHistoryStore()
+	store.path = path
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []GameHistoryEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("could not read history store '%s': %w", path, err)
+	}
+	store.Append(entries...)
+	return store, nil
+}
+
+// Append adds entries to the store and returns how many of them were new.
+// Entries already stored are replaced by the newer copy.
+func (s *HistoryStore) Append(entries ...GameHistoryEntry) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	added := 0
+	for _, entry := range entries {
+		games, ok := s.entries[entry.UserID]
+		if !ok {
+			games = make(map[string]GameHistoryEntry)
+			s.entries[entry.UserID] = games
+		}
+		if _, exists := games[entry.GameID]; !exists {
+			added++
+		}
+		games[entry.GameID] = entry
+	}
+	return added
+}
+
+// Has reports whether the store holds the given user's entry for a game.
+func (s *HistoryStore) Has(userID, gameID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.entries[userID][gameID]
+	return ok
+}
+
+// History returns every stored entry for a user, oldest game first.
+func (s *HistoryStore) History(userID string) []GameHistoryEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return sortedHistory(s.entries[userID])
+}
+
+// Users returns the IDs of every user with stored history, sorted.
+func (s *HistoryStore) Users() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	users := make([]string, 0, len(s.entries))
+	for userID := range s.entries {
+		users = append(users, userID)
+	}
+	sort.Strings(users)
+	return users
+}
+
+// Save writes the store to the file it was opened from.
+// It does nothing for stores created with NewHistoryStore.
+func (s *HistoryStore) Save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	var all []GameHistoryEntry
+	for _, games := range s.entries {
+		all = append(all, sortedHistory(games)...)
+	}
+	s.mu.Unlock()
+
+	data, err := json.Marshal(all)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(s.path, data)
+}
+
+// sortedHistory returns the entries of a game map, oldest game first.
+func sortedHistory(games map[string]GameHistoryEntry) []GameHistoryEntry {
+	history := make([]GameHistoryEntry, 0, len(games))
+	for _, entry := range games {
+		history = append(history, entry)
+	}
+	sort.Slice(history, func(i, j int) bool {
+		if !history[i].CreatedAt.Equal(history[j].CreatedAt.Time) {
+			return history[i].CreatedAt.Before(history[j].CreatedAt.Time)
+		}
+		return history[i].GameID < history[j].GameID
+	})
+	return history
+}
+
+// PollHistory fetches a player's recent games and appends them to the store.
+// It pages further back only while pages still contain unknown games, so regular polls
+// cost a single request once the store has caught up. It returns the number of new games.
+func (c *Client) PollHistory(store *HistoryStore, username string) (int, error) {
+	added := 0
+	it := c.PlayerHistory(username)
+	for it.Next() {
+		n := store.Append(it.Page()...)
+		added += n
+		if n == 0 {
+			break
+		}
+	}
+	if err := it.Err(); err != nil {
+		return added, fmt.Errorf("could not poll history of '%s': %w", username, err)
+	}
+	return added, nil
+}
@@ -0,0 +1,164 @@
+package wolfyclient
+
+import (
+	"fmt"
+
+	"github.com/google/go-querystring/query"
+)
+
+// LeaderboardType selects what the global leaderboard is ranked by.
+type LeaderboardType string
+
+const (
+	LeaderboardElo LeaderboardType = "elo"
+	LeaderboardXP  LeaderboardType = "xp"
+)
+
+// GetLeaderboardPage retrieves one page of the global leaderboard, ranked by Elo or XP.
+// An empty lang returns the worldwide leaderboard; otherwise only players of that
+// language (e.g. "fr" or "en") are ranked. Pages start at 1.
+func (c *Client) GetLeaderboardPage(kind LeaderboardType, lang string, page int) ([]LeaderboardEntry, error) {
+	values, err := query.Values(LeaderboardQuery{Type: kind, Lang: lang, Page: page})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.newRequest("GET", "/leaderboard/global?"+values.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []LeaderboardEntry
+	if err := c.do(req, &entries); err != nil {
+		return nil, fmt.Errorf("could not fetch %s leaderboard page %d: %w", kind, page, err)
+	}
+	for _, entry := range entries {
+		c.observeSkin(entry.ID, entry.SkinVersion, entry.SlotID)
+	}
+	return entries, nil
+}
+
+// LeaderboardIterator pages through a global leaderboard, best players first.
+// Iteration stops on an empty page, or on a page that only repeats players already
+// returned, in case the server clamps the page number.
+//
+//	it := client.Leaderboard(wolfyclient.LeaderboardElo, "fr")
+//	for it.Next() {
+//		for i, entry := range it.Page() { position := it.Offset() + i + 1; ... }
+//	}
+//	if err := it.Err(); err != nil { ... }
+type LeaderboardIterator struct {
+	client *Client
+	kind   LeaderboardType
+	lang   string
+	page   int
+	offset int
+	seen   map[string]bool
+	items  []LeaderboardEntry
+	done   bool
+	err    error
+}
+
+// Leaderboard returns an iterator over the global leaderboard of the given type and language.
+func (c *Client) Leaderboard(kind LeaderboardType, lang string) *LeaderboardIterator {
+	return &LeaderboardIterator{
+		client: c,
+		kind:   kind,
+		lang:   lang,
+		seen:   make(map[string]bool),
+	}
+}
+
+// Next fetches the next page. It returns false when there are no more players or an
+// error occurred; check Err to tell the two apart.
+func (it *LeaderboardIterator) Next() bool {
+	if it.done {
+		return false
+	}
+
+	entries, err := it.client.GetLeaderboardPage(it.kind, it.lang, it.page+1)
+	if err != nil {
+		it.err, it.done = err, true
+		return false
+	}
+	it.page++
+	it.offset += len(it.items)
+
+	it.items = it.items[:0]
+	for _, entry := range entries {
+		if !it.seen[entry.ID] {
+			it.seen[entry.ID] = true
+			it.items = append(it.items, entry)
+		}
+	}
+	if len(it.items) == 0 {
+		it.done = true
+		return false
+	}
+	return true
+}
+
+// Page returns the players fetched by the last call to Next.
+// The slice is reused by the following call to Next.
+func (it *LeaderboardIterator) Page() []LeaderboardEntry {
+	return it.items
+}
+
+// Offset returns the number of players ranked above the current page,
+// so that the position of Page()[i] is Offset()+i+1.
+func (it *LeaderboardIterator) Offset() int {
+	return it.offset
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *LeaderboardIterator) Err() error {
+	return it.err
+}
+
+// GetTopPlayers returns the n best players of a global leaderboard, fetching as many
+// pages as needed. It returns fewer players if the leaderboard is shorter.
+func (c *Client) GetTopPlayers(kind LeaderboardType, lang string, n int) ([]LeaderboardEntry, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+
+	var top []LeaderboardEntry
+	it := c.Leaderboard(kind, lang)
+	for len(top) < n && it.Next() {
+		top = append(top, it.Page()...)
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return top[:min(n, len(top))], nil
+}
+
+// LocatePlayers scans the top limit players of a global leaderboard and returns the
+// 1-based position of each given user ID found there. Users outside the top limit are
+// absent from the result. Scanning stops early once every user has been found.
+func (c *Client) LocatePlayers(kind LeaderboardType, lang string, userIDs []string, limit int) (map[string]int, error) {
+	wanted := make(map[string]bool, len(userIDs))
+	for _, id := range userIDs {
+		wanted[id] = true
+	}
+
+	positions := make(map[string]int, len(userIDs))
+	it := c.Leaderboard(kind, lang)
+	scanned := 0
+	for len(positions) < len(wanted) && scanned < limit && it.Next() {
+		scanned = it.Offset() + len(it.Page())
+		for i, entry := range it.Page() {
+			position := it.Offset() + i + 1
+			if position > limit {
+				break
+			}
+			if wanted[entry.ID] {
+				positions[entry.ID] = position
+			}
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return positions, nil
+}
@@ -1,6 +1,7 @@
 package wolfyclient
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -29,7 +30,35 @@ const (
 // GetUserSkin fetches the rendered skin image for a given user ID.
 // It returns the raw image data as a byte slice.
 // The 'size' parameter is only applied if the format is PNG.
+// If a skin cache is set and the user's skin version is known, the render is served from the cache.
 func (c *Client) GetUserSkin(userID, format, profile, size string) ([]byte, error) {
+	if c.skinCache == nil {
+		return c.fetchUserSkin(userID, format, profile, size)
+	}
+
+	// The version is only known once the user was seen through a player lookup.
+	// Without it we cannot tell whether a cached render is stale, so we skip the cache.
+	version, ok := c.skinCache.Version(userID)
+	if !ok {
+		return c.fetchUserSkin(userID, format, profile, size)
+	}
+
+	key := SkinCacheKey{UserID: userID, SkinVersion: version, Format: format, Profile: profile, Size: size}
+	if cached, found, err := c.skinCache.Get(key); err == nil && found {
+		return cached, nil
+	}
+
+	imageData, err := c.fetchUserSkin(userID, format, profile, size)
+	if err != nil {
+		return nil, err
+	}
+	// A render that could not be cached is still a valid render.
+	_ = c.skinCache.Put(key, imageData)
+	return imageData, nil
+}
+
+// fetchUserSkin downloads a skin render from the server, bypassing any cache.
+func (c *Client) fetchUserSkin(userID, format, profile, size string) ([]byte, error) {
 	// 1. Build the base URL
 	skinURL := fmt.Sprintf("https://wolfy.net/api/skin/render/user.%s?id=%s", format, userID)
 
@@ -50,6 +79,9 @@ func (c *Client) GetUserSkin(userID, format, profile, size string) ([]byte, erro
 	}
 	req.Header.Set("User-Agent", c.defaultHeaders["User-Agent"])
 
+	if err := c.wait(req.Context()); err != nil {
+		return nil, err
+	}
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return nil, err
@@ -68,19 +100,96 @@ func (c *Client) GetUserSkin(userID, format, profile, size string) ([]byte, erro
 	return imageData, nil
 }
 
+// observePlayer runs the client's optional hooks on a freshly fetched profile.
+func (c *Client) observePlayer(playerInfo *PlayerInfoResponse) {
+	c.observeSkin(playerInfo.User.ID, playerInfo.User.SkinVersion, playerInfo.User.SlotID)
+
+	if c.unknownStatKeys != nil {
+		if err := playerInfo.Statistics.ValidateStatKeys(); err != nil {
+			c.unknownStatKeys(playerInfo.User, err.(*UnknownStatKeysError))
+		}
+	}
+}
+
+// observeSkin feeds a player's current skin version to the skin cache, if one is set.
+func (c *Client) observeSkin(userID, skinVersion, slotID string) {
+	if c.skinCache == nil {
+		return
+	}
+	// A failure to drop stale renders must not fail the lookup that triggered it;
+	// the next observation will retry the invalidation.
+	_ = c.skinCache.Observe(userID, skinVersion, slotID)
+}
+
 // GetPlayerInfo retrieves the detailed profile for a given player by their username.
 func (c *Client) GetPlayerInfo(username string) (*PlayerInfoResponse, error) {
-	path := fmt.Sprintf("/leaderboard/player/%s", username)
-	req, err := c.newRequest("GET", path, nil)
+	return c.GetPlayerInfoContext(context.Background(), username)
+}
+
+// GetPlayerInfoContext is like GetPlayerInfo, but the context can cancel the request
+// or the wait for the rate limiter.
+// Concurrent lookups of the same username share a single request, which is only canceled
+// once every caller sharing it has canceled its context. Each caller gets its own copy of
+// the profile, which it may modify freely.
+func (c *Client) GetPlayerInfoContext(ctx context.Context, username string) (*PlayerInfoResponse, error) {
+	v, err := c.playerFlights.do(ctx, username, func(ctx context.Context) (any, error) {
+		path := fmt.Sprintf("/leaderboard/player/%s", username)
+		req, err := c.newRequest("GET", path, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var playerInfo PlayerInfoResponse
+		if err := c.do(req.WithContext(ctx), &playerInfo); err != nil {
+			return nil, err
+		}
+		c.observePlayer(&playerInfo)
+		return &playerInfo, nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	var playerInfo PlayerInfoResponse
-	if err := c.do(req, &playerInfo); err != nil {
-		return nil, err
+	return v.(*PlayerInfoResponse).clone(), nil
+}
+
+// clone returns a deep copy of the profile, so that callers sharing a request do not
+// share its slices and maps.
+func (p *PlayerInfoResponse) clone() *PlayerInfoResponse {
+	c := *p
+	c.Statistics.Laurels = cloneMap(p.Statistics.Laurels)
+	c.Statistics.Roles = append([]RoleStats(nil), p.Statistics.Roles...)
+	for i := range c.Statistics.Roles {
+		c.Statistics.Roles[i].AdvancedStats = cloneMap(c.Statistics.Roles[i].AdvancedStats)
+	}
+
+	c.History = append([]GameHistoryEntry(nil), p.History...)
+	for i := range c.History {
+		entry := &c.History[i]
+		if entry.DeathReason != nil {
+			death := *entry.DeathReason
+			death.VotersIDs = append([]string(nil), death.VotersIDs...)
+			entry.DeathReason = &death
+		}
+		if entry.Game.NextID != nil {
+			nextID := *entry.Game.NextID
+			entry.Game.NextID = &nextID
+		}
+		entry.Game.Settings.Roles = cloneMap(entry.Game.Settings.Roles)
+	}
+	return &c
+}
+
+// cloneMap returns a copy of m, or nil if m is nil.
+func cloneMap[K comparable, V any](m map[K]V) map[K]V {
+	if m == nil {
+		return nil
 	}
-	return &playerInfo, nil
+	c := make(map[K]V, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
 }
 
 // FindUserID finds a user by their exact username and returns their unique ID.
@@ -96,4 +205,3 @@ func (c *Client) GetUserID(username string) (string, error) {
 	// Success! Return the ID from the player data.
 	return playerInfo.User.ID, nil
 }
-
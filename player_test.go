@@ -0,0 +1,33 @@
+package wolfyclient
+
+import "testing"
+
+func TestPlayerInfoCloneIsDeep(t *testing.T) {
+	nextID := "g2"
+	original := &PlayerInfoResponse{
+		Statistics: PlayerStatistics{
+			Laurels: map[string]int{"wins": 1},
+			Roles:   []RoleStats{{ID: "seer", AdvancedStats: map[string]float64{"x": 1}}},
+		},
+		History: []GameHistoryEntry{{
+			GameID:      "g1",
+			DeathReason: &DeathReason{Type: DeathVote, VotersIDs: []string{"a"}},
+			Game:        Game{NextID: &nextID, Settings: GameSettings{Roles: map[Role]int{"seer": 1}}},
+		}},
+	}
+
+	c := original.clone()
+	c.Statistics.Laurels["wins"] = 2
+	c.Statistics.Roles[0].AdvancedStats["x"] = 2
+	c.History[0].GameID = "changed"
+	c.History[0].DeathReason.VotersIDs[0] = "changed"
+	*c.History[0].Game.NextID = "changed"
+	c.History[0].Game.Settings.Roles["seer"] = 2
+
+	entry := original.History[0]
+	if original.Statistics.Laurels["wins"] != 1 || original.Statistics.Roles[0].AdvancedStats["x"] != 1 ||
+		entry.GameID != "g1" || entry.DeathReason.VotersIDs[0] != "a" || *entry.Game.NextID != "g2" ||
+		entry.Game.Settings.Roles["seer"] != 1 {
+		t.Errorf("modifying the clone changed the original: %+v", original)
+	}
+}
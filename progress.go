@@ -0,0 +1,207 @@
+package wolfyclient
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ProgressSource tells where a progress point comes from.
+type ProgressSource string
+
+const (
+	ProgressSnapshot ProgressSource = "snapshot" // Current values read from a profile.
+	ProgressGame     ProgressSource = "game"     // Values reconstructed after a game from its deltas.
+)
+
+// ProgressPoint is a player's Elo and XP at a point in time.
+type ProgressPoint struct {
+	Time     time.Time      `json:"time"`
+	Source   ProgressSource `json:"source"`
+	Elo      int            `json:"elo"`
+	XP       int            `json:"xp"`
+	Rank     int            `json:"rank,omitempty"`     // Only known for snapshots.
+	Percent  float64        `json:"percent,omitempty"`  // Ranking percentile, only known for snapshots.
+	GameID   string         `json:"gameId,omitempty"`   // Set for game points.
+	EloDelta int            `json:"eloDelta,omitempty"` // Set for game points.
+	XPDelta  int            `json:"xpDelta,omitempty"`  // Set for game points.
+}
+
+// RankUp is a change of rank detected between two consecutive snapshots.
+type RankUp struct {
+	From  int
+	To    int
+	After time.Time // Time of the last snapshot with the old rank.
+	By    time.Time // Time of the first snapshot with the new rank.
+}
+
+// ProgressTracker reconstructs a player's Elo and XP curve.
+//
+// Profiles only expose current values, while each history entry carries the Elo and XP
+// won or lost in that game. The tracker anchors the curve on the snapshots it is given and
+// walks the game deltas from there, so that every game gets the values the player had after it.
+// It is safe for concurrent use.
+type ProgressTracker struct {
+	mu        sync.Mutex
+	userID    string
+	snapshots []ProgressPoint
+	games     map[string]GameHistoryEntry
+}
+
+// NewProgressTracker creates a tracker for the player with the given ID.
+func NewProgressTracker(userID string) *ProgressTracker {
+	return &ProgressTracker{
+		userID: userID,
+		games:  make(map[string]GameHistoryEntry),
+	}
+}
+
+// Observe records a snapshot of a fetched profile, taken now, along with its history.
+func (t *ProgressTracker) Observe(info *PlayerInfoResponse) {
+	t.AddSnapshot(info.User, time.Now())
+	t.AddHistory(info.History...)
+}
+
+// AddSnapshot records the player's current values as read at the given time.
+// Snapshots of other players are ignored.
+func (t *ProgressTracker) AddSnapshot(user PlayerUser, at time.Time) {
+	if user.ID != t.userID {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.snapshots = append(t.snapshots, ProgressPoint{
+		Time:    at,
+		Source:  ProgressSnapshot,
+		Elo:     user.Elo,
+		XP:      user.XP,
+		Rank:    user.Rank,
+		Percent: user.Ranking.Percent,
+	})
+	sort.SliceStable(t.snapshots, func(i, j int) bool { return t.snapshots[i].Time.Before(t.snapshots[j].Time) })
+}
+
+// AddHistory records game entries. Entries of other players and duplicates are ignored.
+func (t *ProgressTracker) AddHistory(entries ...GameHistoryEntry) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, entry := range entries {
+		if entry.UserID == t.userID {
+			t.games[entry.GameID] = entry
+		}
+	}
+}
+
+// Series returns the reconstructed curve, oldest point first.
+// Games played before the first snapshot are walked back from it; games played after
+// the last snapshot are walked forward from it. Without any snapshot, no game can be placed
+// and the series is empty.
+func (t *ProgressTracker) Series() []ProgressPoint {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.snapshots) == 0 {
+		return nil
+	}
+
+	// 1. Merge snapshots and games into a single timeline.
+	points := append([]ProgressPoint(nil), t.snapshots...)
+	for _, entry := range t.games {
+		points = append(points, ProgressPoint{
+			Time:     entry.CreatedAt.Time,
+			Source:   ProgressGame,
+			GameID:   entry.GameID,
+			EloDelta: entry.Elo,
+			XPDelta:  entry.XP,
+		})
+	}
+	sort.SliceStable(points, func(i, j int) bool {
+		if !points[i].Time.Equal(points[j].Time) {
+			return points[i].Time.Before(points[j].Time)
+		}
+		return points[i].Source == ProgressGame && points[j].Source == ProgressSnapshot
+	})
+
+	// 2. Backward pass: each game takes the values of the next anchor minus the deltas in between.
+	lastSnapshot := -1
+	var elo, xp int
+	anchored := false
+	for i := len(points) - 1; i >= 0; i-- {
+		p := &points[i]
+		if p.Source == ProgressSnapshot {
+			elo, xp, anchored = p.Elo, p.XP, true
+			if lastSnapshot == -1 {
+				lastSnapshot = i
+			}
+			continue
+		}
+		if anchored {
+			p.Elo, p.XP = elo, xp
+			elo -= p.EloDelta
+			xp -= p.XPDelta
+		}
+	}
+
+	// 3. Forward pass: games after the last snapshot build on top of it.
+	elo, xp = points[lastSnapshot].Elo, points[lastSnapshot].XP
+	for i := lastSnapshot + 1; i < len(points); i++ {
+		elo += points[i].EloDelta
+		xp += points[i].XPDelta
+		points[i].Elo, points[i].XP = elo, xp
+	}
+
+	return points
+}
+
+// RankUps returns every rank change seen between consecutive snapshots, oldest first.
+func (t *ProgressTracker) RankUps() []RankUp {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var rankUps []RankUp
+	for i := 1; i < len(t.snapshots); i++ {
+		prev, curr := t.snapshots[i-1], t.snapshots[i]
+		if curr.Rank > prev.Rank {
+			rankUps = append(rankUps, RankUp{From: prev.Rank, To: curr.Rank, After: prev.Time, By: curr.Time})
+		}
+	}
+	return rankUps
+}
+
+// WriteProgressCSV writes the points as CSV with a header row, for charting tools.
+func WriteProgressCSV(w io.Writer, points []ProgressPoint) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"time", "source", "elo", "xp", "rank", "percent", "game_id", "elo_delta", "xp_delta"})
+	for _, p := range points {
+		rank, percent := "", ""
+		if p.Source == ProgressSnapshot {
+			rank = strconv.Itoa(p.Rank)
+			percent = strconv.FormatFloat(p.Percent, 'f', -1, 64)
+		}
+		cw.Write([]string{
+			p.Time.UTC().Format(time.RFC3339),
+			string(p.Source),
+			strconv.Itoa(p.Elo),
+			strconv.Itoa(p.XP),
+			rank,
+			percent,
+			p.GameID,
+			strconv.Itoa(p.EloDelta),
+			strconv.Itoa(p.XPDelta),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteProgressJSON writes the points as a JSON array.
+func WriteProgressJSON(w io.Writer, points []ProgressPoint) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(points)
+}
@@ -0,0 +1,137 @@
+package wolfyclient
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket: it allows bursts of up to 'burst' requests,
+// then one request per interval.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	burst    float64
+	tokens   float64
+	last     time.Time
+}
+
+func newRateLimiter(requestsPerSecond float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{
+		interval: time.Duration(float64(time.Second) / requestsPerSecond),
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}
+}
+
+// wait blocks until a request may be sent, or until ctx is done.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+float64(now.Sub(l.last))/float64(l.interval))
+	l.last = now
+
+	// Take a token, possibly going into debt; the debt is the time we have to wait.
+	l.tokens--
+	if l.tokens >= 0 {
+		l.mu.Unlock()
+		return nil
+	}
+	delay := time.Duration(-l.tokens * float64(l.interval))
+	l.mu.Unlock()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Give the reserved token back, since we will not use it.
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// flightGroup deduplicates concurrent calls sharing the same key:
+// while a call is in flight, later callers wait for it and receive its result.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done     chan struct{} // Closed once val, err and panicked are set.
+	val      any
+	err      error
+	panicked any // The value fn panicked with, if it did.
+
+	waiters int                // Callers still waiting for the result.
+	cancel  context.CancelFunc // Cancels the shared call's context.
+}
+
+// do runs fn once for all concurrent callers using the same key.
+//
+// fn runs in its own goroutine, on a context detached from the first caller's: it keeps
+// the caller's values but not its cancellation, so one caller giving up does not fail the
+// others. Each caller stops waiting when its own ctx is done, and the shared call is only
+// canceled once every caller has given up. If fn panics, the callers waiting for it panic too.
+func (g *flightGroup) do(ctx context.Context, key string, fn func(context.Context) (any, error)) (any, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+	call, ok := g.calls[key]
+	if !ok {
+		shared, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &flightCall{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = call
+		go g.run(shared, key, call, fn)
+	}
+	call.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		if call.panicked != nil {
+			panic(call.panicked)
+		}
+		return call.val, call.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			// Nobody wants the result anymore: stop the call, and let the next caller start afresh.
+			call.cancel()
+			if g.calls[key] == call {
+				delete(g.calls, key)
+			}
+		}
+		g.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+// run executes a shared call and publishes its result, even if fn panics.
+func (g *flightGroup) run(ctx context.Context, key string, call *flightCall, fn func(context.Context) (any, error)) {
+	defer func() {
+		if r := recover(); r != nil {
+			call.panicked = r
+		}
+		call.cancel()
+
+		g.mu.Lock()
+		if g.calls[key] == call {
+			delete(g.calls, key)
+		}
+		g.mu.Unlock()
+		close(call.done)
+	}()
+
+	call.val, call.err = fn(ctx)
+}
@@ -0,0 +1,90 @@
+package wolfyclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFlightGroupCallerCancelDoesNotFailOthers(t *testing.T) {
+	var g flightGroup
+	release := make(chan struct{})
+	fn := func(ctx context.Context) (any, error) {
+		select {
+		case <-release:
+			return "ok", nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	first, cancelFirst := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := g.do(first, "alice", fn)
+		firstErr <- err
+	}()
+
+	// Wait for the first call to be in flight before joining it.
+	for {
+		g.mu.Lock()
+		_, inFlight := g.calls["alice"]
+		g.mu.Unlock()
+		if inFlight {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	second := make(chan any, 1)
+	go func() {
+		v, err := g.do(context.Background(), "alice", fn)
+		if err != nil {
+			second <- err
+			return
+		}
+		second <- v
+	}()
+
+	// Only cancel once both callers share the call, otherwise the second would start its own.
+	for {
+		g.mu.Lock()
+		waiters := g.calls["alice"].waiters
+		g.mu.Unlock()
+		if waiters == 2 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	cancelFirst()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("first caller: got %v, want context.Canceled", err)
+	}
+
+	close(release)
+	if v := <-second; v != "ok" {
+		t.Fatalf("second caller: got %v, want ok", v)
+	}
+}
+
+func TestFlightGroupPanicReleasesKey(t *testing.T) {
+	var g flightGroup
+
+	func() {
+		defer func() {
+			if r := recover(); r != "boom" {
+				t.Fatalf("recovered %v, want boom", r)
+			}
+		}()
+		g.do(context.Background(), "bob", func(context.Context) (any, error) { panic("boom") })
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	v, err := g.do(ctx, "bob", func(context.Context) (any, error) { return "ok", nil })
+	if err != nil || v != "ok" {
+		t.Fatalf("call after panic: got %v, %v", v, err)
+	}
+}
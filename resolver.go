@@ -0,0 +1,228 @@
+package wolfyclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Rename records that a known user ID showed up with a different username.
+type Rename struct {
+	UserID      string    `json:"userId"`
+	OldUsername string    `json:"oldUsername"`
+	NewUsername string    `json:"newUsername"`
+	DetectedAt  time.Time `json:"detectedAt"`
+}
+
+// resolvedUser is a cached username <-> ID pair.
+type resolvedUser struct {
+	ID       string    `json:"id"`
+	Username string    `json:"username"`
+	SeenAt   time.Time `json:"seenAt"`
+}
+
+// resolverState is the on-disk form of a Resolver.
+type resolverState struct {
+	Users   []resolvedUser `json:"users"`
+	Renames []Rename       `json:"renames"`
+}
+
+// Resolver maps usernames to user IDs and back, caching what it learns.
+//
+// GetUserID fetches a whole profile just to read its ID. The resolver answers from its
+// cache while entries are fresher than its TTL, and otherwise tries the lightweight
+// autocomplete search before falling back to a profile lookup. Since usernames can change,
+// IDs are the source of truth: when a known ID shows up with a new username, the rename is
+// recorded and the old name stops resolving. It is safe for concurrent use.
+type Resolver struct {
+	client *Client
+	ttl    time.Duration
+	path   string
+
+	mu      sync.Mutex
+	byID    map[string]resolvedUser
+	byName  map[string]string // lowercased username -> ID
+	renames []Rename
+
+	// OnRename, if set, is called for every rename detected. It must not call back into the resolver.
+	OnRename func(Rename)
+}
+
+// NewResolver creates an in-memory resolver whose entries are trusted for ttl.
+// A ttl of zero or less means entries never expire.
+func NewResolver(client *Client, ttl time.Duration) *Resolver {
+	return &Resolver{
+		client: client,
+		ttl:    ttl,
+		byID:   make(map[string]resolvedUser),
+		byName: make(map[string]string),
+	}
+}
+
+// OpenResolver loads a resolver cache from a JSON file, or starts an empty one if the file
+// does not exist yet. Call Save to write it back.
+func OpenResolver(client *Client, path string, ttl time.Duration) (*Resolver, error) {
+	r := NewResolver(client, ttl)
+	r.path = path
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return r, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var state resolverState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("could not read resolver cache '%s': %w", path, err)
+	}
+	for _, user := range state.Users {
+		r.byID[user.ID] = user
+		r.byName[strings.ToLower(user.Username)] = user.ID
+	}
+	r.renames = state.Renames
+	return r, nil
+}
+
+// Save writes the cache to the file it was opened from.
+// It does nothing for resolvers created with NewResolver.
+func (r *Resolver) Save() error {
+	if r.path == "" {
+		return nil
+	}
+
+	r.mu.Lock()
+	state := resolverState{Renames: r.renames}
+	for _, user := range r.byID {
+		state.Users = append(state.Users, user)
+	}
+	r.mu.Unlock()
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(r.path, data)
+}
+
+// Observe records a username <-> ID pair seen anywhere, e.g. in a leaderboard.
+// It returns the rename it detected, if the ID was known under another username.
+// Usernames are case-insensitive: a change of case only updates the recorded spelling.
+func (r *Resolver) Observe(userID, username string) *Rename {
+	if userID == "" || username == "" {
+		return nil
+	}
+
+	r.mu.Lock()
+	var rename *Rename
+	if previous, ok := r.byID[userID]; ok && !strings.EqualFold(previous.Username, username) {
+		rename = &Rename{UserID: userID, OldUsername: previous.Username, NewUsername: username, DetectedAt: time.Now()}
+		r.renames = append(r.renames, *rename)
+		if r.byName[strings.ToLower(previous.Username)] == userID {
+			delete(r.byName, strings.ToLower(previous.Username))
+		}
+	}
+	r.byID[userID] = resolvedUser{ID: userID, Username: username, SeenAt: time.Now()}
+	r.byName[strings.ToLower(username)] = userID
+	onRename := r.OnRename
+	r.mu.Unlock()
+
+	if rename != nil && onRename != nil {
+		onRename(*rename)
+	}
+	return rename
+}
+
+// ObservePlayer records the user of a fetched profile.
+func (r *Resolver) ObservePlayer(info *PlayerInfoResponse) *Rename {
+	return r.Observe(info.User.ID, info.User.Username)
+}
+
+// ObserveLeaderboard records every user of a leaderboard and returns the renames detected.
+func (r *Resolver) ObserveLeaderboard(entries []LeaderboardEntry) []Rename {
+	var renames []Rename
+	for _, entry := range entries {
+		if rename := r.Observe(entry.ID, entry.Username); rename != nil {
+			renames = append(renames, *rename)
+		}
+	}
+	return renames
+}
+
+// cachedID returns the cached ID for a username if it is still fresh.
+func (r *Resolver) cachedID(username string) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id, ok := r.byName[strings.ToLower(username)]
+	if !ok {
+		return "", false
+	}
+	if r.ttl > 0 && time.Since(r.byID[id].SeenAt) > r.ttl {
+		return "", false
+	}
+	return id, true
+}
+
+// ResolveID returns the user ID of a username, ignoring case.
+// Errors other than the user not being found, e.g. network errors, are returned as they are
+// rather than retried with the heavier profile lookup.
+func (r *Resolver) ResolveID(username string) (string, error) {
+	if id, ok := r.cachedID(username); ok {
+		return id, nil
+	}
+
+	// 1. The autocomplete search is much lighter than a full profile; use it when it has an exact hit.
+	results, err := r.client.SearchUsers(username)
+	if err != nil && !IsNotFound(err) {
+		return "", fmt.Errorf("could not search user '%s': %w", username, err)
+	}
+	for _, user := range results {
+		if strings.EqualFold(user.Username, username) {
+			r.Observe(user.ID, user.Username)
+			return user.ID, nil
+		}
+	}
+
+	// 2. Fall back to the profile lookup, which also works for names autocomplete does not return.
+	info, err := r.client.GetPlayerInfo(username)
+	if err != nil {
+		return "", fmt.Errorf("could not find user '%s': %w", username, err)
+	}
+	r.ObservePlayer(info)
+	return info.User.ID, nil
+}
+
+// Username returns the last username seen for a user ID.
+// There is no API to look a user up by ID, so only IDs the resolver has seen can be resolved.
+func (r *Resolver) Username(userID string) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, ok := r.byID[userID]
+	return user.Username, ok
+}
+
+// Renames returns every rename detected so far, oldest first.
+func (r *Resolver) Renames() []Rename {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Rename(nil), r.renames...)
+}
+
+// Forget removes a user from the cache, e.g. after a lookup by ID failed.
+func (r *Resolver) Forget(userID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if user, ok := r.byID[userID]; ok {
+		delete(r.byID, userID)
+		if r.byName[strings.ToLower(user.Username)] == userID {
+			delete(r.byName, strings.ToLower(user.Username))
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package wolfyclient
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestResolverObserveCaseChange(t *testing.T) {
+	r := NewResolver(nil, 0)
+	r.Observe("1", "alice")
+	if rename := r.Observe("1", "Alice"); rename != nil {
+		t.Errorf("case change reported as rename %+v", rename)
+	}
+	if name, _ := r.Username("1"); name != "Alice" {
+		t.Errorf("Username = %q, want the new spelling Alice", name)
+	}
+	if rename := r.Observe("1", "alicia"); rename == nil || rename.OldUsername != "Alice" {
+		t.Errorf("rename = %+v, want one from Alice", rename)
+	}
+}
+
+func TestResolverResolveIDFallback(t *testing.T) {
+	var profileLookups atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/social/autocomplete/", func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimPrefix(r.URL.Path, "/social/autocomplete/") {
+		case "broken":
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		case "gone":
+			http.NotFound(w, r)
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`[]`))
+		}
+	})
+	mux.HandleFunc("/leaderboard/player/", func(w http.ResponseWriter, r *http.Request) {
+		profileLookups.Add(1)
+		username := strings.TrimPrefix(r.URL.Path, "/leaderboard/player/")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(PlayerInfoResponse{User: PlayerUser{ID: "id-" + username, Username: username}})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	base, _ := url.Parse(server.URL + "/")
+	r := NewResolver(&Client{baseURL: base, httpClient: server.Client()}, 0)
+
+	// A miss and a not-found search both fall back to the profile lookup.
+	for _, username := range []string{"missing", "gone"} {
+		if id, err := r.ResolveID(username); err != nil || id != "id-"+username {
+			t.Errorf("ResolveID(%s) = %q, %v", username, id, err)
+		}
+	}
+	if n := profileLookups.Load(); n != 2 {
+		t.Errorf("%d profile lookups, want 2", n)
+	}
+
+	// Other search failures are returned.
+	if _, err := r.ResolveID("broken"); err == nil || IsNotFound(err) {
+		t.Errorf("ResolveID(broken) error = %v, want the search failure", err)
+	}
+	if n := profileLookups.Load(); n != 2 {
+		t.Errorf("search failure fell back to a profile lookup")
+	}
+}
@@ -0,0 +1,338 @@
+package wolfyclient
+
+import (
+	"sort"
+	"sync"
+)
+
+// Role is the identifier of a game role, as found in RoleStats.ID,
+// GameHistoryEntry.Role and the keys of GameSettings.Roles.
+type Role string
+
+// Alignment is the camp a role plays for.
+type Alignment string
+
+// Known alignments. Innocent and threat match the keys of OverallGameStats.
+const (
+	AlignmentUnknown  Alignment = "unknown"
+	AlignmentInnocent Alignment = "innocent" // Wins with the village.
+	AlignmentThreat   Alignment = "threat"   // Wins with the werewolves.
+	AlignmentSolo     Alignment = "solo"     // Wins alone, against both camps.
+)
+
+// Known roles.
+const (
+	RoleVillager      Role = "villager"
+	RoleSeer          Role = "seer"
+	RoleWitch         Role = "witch"
+	RoleHunter        Role = "hunter"
+	RoleCupid         Role = "cupid"
+	RoleLittleGirl    Role = "little_girl"
+	RoleGuard         Role = "guard"
+	RoleRaven         Role = "raven"
+	RoleElder         Role = "elder"
+	RoleFox           Role = "fox"
+	RoleBearTamer     Role = "bear_tamer"
+	RoleVillageIdiot  Role = "idiot"
+	RoleShaman        Role = "shaman"
+	RoleWerewolf      Role = "werewolf"
+	RoleBigBadWolf    Role = "big_bad_wolf"
+	RoleInfectFather  Role = "infect"
+	RoleTalkativeWolf Role = "talkative_wolf"
+	RoleWhiteWerewolf Role = "white_werewolf"
+	RolePyromaniac    Role = "pyromaniac"
+	RolePiper         Role = "piper"
+	RoleAngel         Role = "angel"
+)
+
+// Languages with display names and descriptions in the role registry.
+const (
+	LangFrench  = "fr"
+	LangEnglish = "en"
+)
+
+// RoleInfo holds the metadata of a role.
+// Names and Descriptions are keyed by language code, e.g. LangFrench.
+type RoleInfo struct {
+	ID           Role
+	Alignment    Alignment
+	Names        map[string]string
+	Descriptions map[string]string
+}
+
+// Name returns the display name of the role in the given language,
+// falling back to English and then to the raw role ID.
+func (i RoleInfo) Name(lang string) string {
+	if name, ok := i.Names[lang]; ok {
+		return name
+	}
+	if name, ok := i.Names[LangEnglish]; ok {
+		return name
+	}
+	return string(i.ID)
+}
+
+// Description returns the description of the role in the given language, falling back to English.
+func (i RoleInfo) Description(lang string) string {
+	if description, ok := i.Descriptions[lang]; ok {
+		return description
+	}
+	return i.Descriptions[LangEnglish]
+}
+
+var (
+	rolesMu  sync.RWMutex
+	registry = map[Role]RoleInfo{}
+)
+
+func init() {
+	for _, info := range builtinRoles {
+		registry[info.ID] = info
+	}
+}
+
+// RegisterRole adds a role to the registry or replaces an existing one.
+// Use it to describe roles added to Wolfy after this library was released.
+func RegisterRole(info RoleInfo) {
+	rolesMu.Lock()
+	defer rolesMu.Unlock()
+	registry[info.ID] = info
+}
+
+// LookupRole returns the registered metadata of a role.
+func LookupRole(id Role) (RoleInfo, bool) {
+	rolesMu.RLock()
+	defer rolesMu.RUnlock()
+	info, ok := registry[id]
+	return info, ok
+}
+
+// Roles returns the metadata of every registered role, sorted by ID.
+func Roles() []RoleInfo {
+	rolesMu.RLock()
+	defer rolesMu.RUnlock()
+
+	roles := make([]RoleInfo, 0, len(registry))
+	for _, info := range registry {
+		roles = append(roles, info)
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i].ID < roles[j].ID })
+	return roles
+}
+
+// Info returns the role's metadata. Unregistered roles get an AlignmentUnknown
+// entry whose name is the raw ID, so they can still be displayed.
+func (r Role) Info() RoleInfo {
+	if info, ok := LookupRole(r); ok {
+		return info
+	}
+	return RoleInfo{ID: r, Alignment: AlignmentUnknown}
+}
+
+// Alignment returns the camp the role plays for.
+func (r Role) Alignment() Alignment {
+	return r.Info().Alignment
+}
+
+// Name returns the display name of the role in the given language.
+func (r Role) Name(lang string) string {
+	return r.Info().Name(lang)
+}
+
+func (r Role) String() string { return string(r) }
+
+// RolesByAlignment groups the player's per-role statistics by camp.
+func (s PlayerStatistics) RolesByAlignment() map[Alignment][]RoleStats {
+	groups := make(map[Alignment][]RoleStats)
+	for _, stats := range s.Roles {
+		alignment := stats.ID.Alignment()
+		groups[alignment] = append(groups[alignment], stats)
+	}
+	return groups
+}
+
+// Composition returns how many seats of the game go to each camp.
+func (g GameSettings) Composition() map[Alignment]int {
+	composition := make(map[Alignment]int)
+	for role, count := range g.Roles {
+		composition[role.Alignment()] += count
+	}
+	return composition
+}
+
+// builtinRoles is the registry content shipped with the library.
+var builtinRoles = []RoleInfo{
+	// --- Innocent ---
+	{
+		ID: RoleVillager, Alignment: AlignmentInnocent,
+		Names: map[string]string{LangFrench: "Villageois", LangEnglish: "Villager"},
+		Descriptions: map[string]string{
+			LangFrench:  "N'a aucun pouvoir, mais sa voix compte pour éliminer les loups.",
+			LangEnglish: "Has no power, but their vote counts to eliminate the wolves.",
+		},
+	},
+	{
+		ID: RoleSeer, Alignment: AlignmentInnocent,
+		Names: map[string]string{LangFrench: "Voyante", LangEnglish: "Seer"},
+		Descriptions: map[string]string{
+			LangFrench:  "Découvre chaque nuit le rôle d'un joueur.",
+			LangEnglish: "Discovers the role of one player each night.",
+		},
+	},
+	{
+		ID: RoleWitch, Alignment: AlignmentInnocent,
+		Names: map[string]string{LangFrench: "Sorcière", LangEnglish: "Witch"},
+		Descriptions: map[string]string{
+			LangFrench:  "Possède une potion de vie et une potion de mort.",
+			LangEnglish: "Owns one healing potion and one poison potion.",
+		},
+	},
+	{
+		ID: RoleHunter, Alignment: AlignmentInnocent,
+		Names: map[string]string{LangFrench: "Chasseur", LangEnglish: "Hunter"},
+		Descriptions: map[string]string{
+			LangFrench:  "En mourant, emporte un joueur de son choix avec lui.",
+			LangEnglish: "Takes a player of their choice down with them when they die.",
+		},
+	},
+	{
+		ID: RoleCupid, Alignment: AlignmentInnocent,
+		Names: map[string]string{LangFrench: "Cupidon", LangEnglish: "Cupid"},
+		Descriptions: map[string]string{
+			LangFrench:  "Désigne deux amoureux qui meurent l'un avec l'autre.",
+			LangEnglish: "Binds two lovers who die together.",
+		},
+	},
+	{
+		ID: RoleLittleGirl, Alignment: AlignmentInnocent,
+		Names: map[string]string{LangFrench: "Petite fille", LangEnglish: "Little Girl"},
+		Descriptions: map[string]string{
+			LangFrench:  "Peut espionner les loups pendant la nuit.",
+			LangEnglish: "Can spy on the werewolves during the night.",
+		},
+	},
+	{
+		ID: RoleGuard, Alignment: AlignmentInnocent,
+		Names: map[string]string{LangFrench: "Garde", LangEnglish: "Guard"},
+		Descriptions: map[string]string{
+			LangFrench:  "Protège chaque nuit un joueur de l'attaque des loups.",
+			LangEnglish: "Protects one player from the werewolves each night.",
+		},
+	},
+	{
+		ID: RoleRaven, Alignment: AlignmentInnocent,
+		Names: map[string]string{LangFrench: "Corbeau", LangEnglish: "Raven"},
+		Descriptions: map[string]string{
+			LangFrench:  "Ajoute deux voix contre le joueur de son choix.",
+			LangEnglish: "Adds two votes against the player of their choice.",
+		},
+	},
+	{
+		ID: RoleElder, Alignment: AlignmentInnocent,
+		Names: map[string]string{LangFrench: "Ancien", LangEnglish: "Elder"},
+		Descriptions: map[string]string{
+			LangFrench:  "Survit à la première attaque des loups.",
+			LangEnglish: "Survives the first werewolf attack.",
+		},
+	},
+	{
+		ID: RoleFox, Alignment: AlignmentInnocent,
+		Names: map[string]string{LangFrench: "Renard", LangEnglish: "Fox"},
+		Descriptions: map[string]string{
+			LangFrench:  "Flaire trois joueurs voisins pour savoir si un loup s'y cache.",
+			LangEnglish: "Sniffs three neighbouring players to learn whether a wolf is among them.",
+		},
+	},
+	{
+		ID: RoleBearTamer, Alignment: AlignmentInnocent,
+		Names: map[string]string{LangFrench: "Montreur d'ours", LangEnglish: "Bear Tamer"},
+		Descriptions: map[string]string{
+			LangFrench:  "Son ours grogne si l'un de ses voisins est un loup.",
+			LangEnglish: "Their bear growls when one of their neighbours is a wolf.",
+		},
+	},
+	{
+		ID: RoleVillageIdiot, Alignment: AlignmentInnocent,
+		Names: map[string]string{LangFrench: "Idiot du village", LangEnglish: "Village Idiot"},
+		Descriptions: map[string]string{
+			LangFrench:  "Survit au vote du village mais perd son droit de vote.",
+			LangEnglish: "Survives the village vote but loses their right to vote.",
+		},
+	},
+	{
+		ID: RoleShaman, Alignment: AlignmentInnocent,
+		Names: map[string]string{LangFrench: "Chaman", LangEnglish: "Shaman"},
+		Descriptions: map[string]string{
+			LangFrench:  "Entend les messages des morts pendant la nuit.",
+			LangEnglish: "Hears the messages of the dead during the night.",
+		},
+	},
+
+	// --- Threat ---
+	{
+		ID: RoleWerewolf, Alignment: AlignmentThreat,
+		Names: map[string]string{LangFrench: "Loup-garou", LangEnglish: "Werewolf"},
+		Descriptions: map[string]string{
+			LangFrench:  "Dévore un villageois chaque nuit avec sa meute.",
+			LangEnglish: "Devours a villager each night with the pack.",
+		},
+	},
+	{
+		ID: RoleBigBadWolf, Alignment: AlignmentThreat,
+		Names: map[string]string{LangFrench: "Grand méchant loup", LangEnglish: "Big Bad Wolf"},
+		Descriptions: map[string]string{
+			LangFrench:  "Dévore une victime supplémentaire tant qu'aucun loup n'est mort.",
+			LangEnglish: "Devours an extra victim as long as no wolf has died.",
+		},
+	},
+	{
+		ID: RoleInfectFather, Alignment: AlignmentThreat,
+		Names: map[string]string{LangFrench: "Infect père des loups", LangEnglish: "Infect Father of Wolves"},
+		Descriptions: map[string]string{
+			LangFrench:  "Peut une fois transformer la victime des loups en loup-garou.",
+			LangEnglish: "Can once turn the wolves' victim into a werewolf.",
+		},
+	},
+	{
+		ID: RoleTalkativeWolf, Alignment: AlignmentThreat,
+		Names: map[string]string{LangFrench: "Loup bavard", LangEnglish: "Talkative Wolf"},
+		Descriptions: map[string]string{
+			LangFrench:  "Doit placer un mot imposé chaque jour sous peine de mourir.",
+			LangEnglish: "Must slip an imposed word into the chat each day or die.",
+		},
+	},
+
+	// --- Solo ---
+	{
+		ID: RoleWhiteWerewolf, Alignment: AlignmentSolo,
+		Names: map[string]string{LangFrench: "Loup blanc", LangEnglish: "White Werewolf"},
+		Descriptions: map[string]string{
+			LangFrench:  "Chasse avec les loups mais peut en dévorer un une nuit sur deux.",
+			LangEnglish: "Hunts with the pack but may devour a wolf every other night.",
+		},
+	},
+	{
+		ID: RolePyromaniac, Alignment: AlignmentSolo,
+		Names: map[string]string{LangFrench: "Pyromane", LangEnglish: "Pyromaniac"},
+		Descriptions: map[string]string{
+			LangFrench:  "Arrose des maisons d'essence puis les enflamme toutes d'un coup.",
+			LangEnglish: "Douses houses in petrol, then sets them all ablaze at once.",
+		},
+	},
+	{
+		ID: RolePiper, Alignment: AlignmentSolo,
+		Names: map[string]string{LangFrench: "Joueur de flûte", LangEnglish: "Piper"},
+		Descriptions: map[string]string{
+			LangFrench:  "Gagne lorsque tous les joueurs vivants sont charmés.",
+			LangEnglish: "Wins once every living player is charmed.",
+		},
+	},
+	{
+		ID: RoleAngel, Alignment: AlignmentSolo,
+		Names: map[string]string{LangFrench: "Ange", LangEnglish: "Angel"},
+		Descriptions: map[string]string{
+			LangFrench:  "Gagne s'il est éliminé lors du premier vote.",
+			LangEnglish: "Wins if they are eliminated by the first vote.",
+		},
+	},
+}
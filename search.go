@@ -0,0 +1,293 @@
+package wolfyclient
+
+import (
+	"container/list"
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// minAutocompleteLength is the shortest search term the autocomplete endpoint answers.
+const minAutocompleteLength = 3
+
+// defaultSearchCacheSize is the number of queries a UserSearch remembers when no size is given.
+const defaultSearchCacheSize = 128
+
+// indexedUsersPerQuery sizes the local user index relative to the query cache:
+// a UserSearch indexes up to this many users per cached query.
+const indexedUsersPerQuery = 20
+
+// SearchMatch tells how closely a username matched the query.
+type SearchMatch int
+
+const (
+	SearchExact  SearchMatch = iota // The username is the query, ignoring case and accents.
+	SearchPrefix                    // The username starts with the query.
+	SearchFuzzy                     // The username is a few edits away from the query.
+)
+
+func (m SearchMatch) String() string {
+	switch m {
+	case SearchExact:
+		return "exact"
+	case SearchPrefix:
+		return "prefix"
+	}
+	return "fuzzy"
+}
+
+// SearchResult is a user found by a search.
+type SearchResult struct {
+	AutocompleteUser
+	Match SearchMatch
+	// Distance is the number of edits between the query and the username; 0 unless Match is SearchFuzzy.
+	Distance int
+	// Profile is the user's profile, only set for enriched results.
+	Profile *PlayerUser
+	// ProfileErr is why the profile of an enriched result could not be fetched.
+	ProfileErr error
+}
+
+// SearchOptions controls a search.
+type SearchOptions struct {
+	Offset int // Number of ranked results to skip, for pagination.
+	Limit  int // Maximum number of results to return; zero means no limit.
+	// Profiles fetches the profile of every returned result. Profiles are fetched with
+	// GetPlayersInfo, so the client's rate limit and batch concurrency apply. Results whose
+	// profile could not be fetched have ProfileErr set instead.
+	Profiles bool
+}
+
+// SearchResults is a page of ranked search results.
+type SearchResults struct {
+	Query   string
+	Total   int // Number of results before pagination.
+	Results []SearchResult
+}
+
+// searchEntry is a cached autocomplete response.
+type searchEntry struct {
+	key     string
+	users   []AutocompleteUser
+	fetched time.Time
+}
+
+// UserSearch finds users by username, on top of the autocomplete endpoint.
+//
+// Autocomplete only matches prefixes and ignores terms shorter than the server minimum.
+// UserSearch indexes the users it has seen most recently, so that short queries and typos can
+// still be answered from that local index, and ranks everything by exact, prefix and fuzzy match.
+// Recent autocomplete responses are cached to cut request volume. Both the query cache and the
+// index are bounded, the index holding indexedUsersPerQuery users per cached query.
+// It is safe for concurrent use.
+type UserSearch struct {
+	client *Client
+	ttl    time.Duration
+	size   int
+
+	mu    sync.Mutex
+	lru   *list.List               // of *searchEntry, most recent first
+	cache map[string]*list.Element // term sent to autocomplete -> element of lru
+	users *list.List               // of AutocompleteUser, most recently seen first
+	known map[string]*list.Element // user ID -> element of users
+}
+
+// NewUserSearch creates a search service caching up to size queries for ttl each.
+// A size of zero or less uses a default; a ttl of zero or less means entries never expire.
+func NewUserSearch(client *Client, size int, ttl time.Duration) *UserSearch {
+	if size <= 0 {
+		size = defaultSearchCacheSize
+	}
+	return &UserSearch{
+		client: client,
+		ttl:    ttl,
+		size:   size,
+		lru:    list.New(),
+		cache:  make(map[string]*list.Element),
+		users:  list.New(),
+		known:  make(map[string]*list.Element),
+	}
+}
+
+// index adds or refreshes a user in the local index, evicting the least recently seen
+// users past its capacity. The caller must hold s.mu.
+func (s *UserSearch) index(user AutocompleteUser) {
+	if user.ID == "" || user.Username == "" {
+		return
+	}
+	if elem, ok := s.known[user.ID]; ok {
+		elem.Value = user
+		s.users.MoveToFront(elem)
+		return
+	}
+	s.known[user.ID] = s.users.PushFront(user)
+	for s.users.Len() > s.size*indexedUsersPerQuery {
+		oldest := s.users.Back()
+		s.users.Remove(oldest)
+		delete(s.known, oldest.Value.(AutocompleteUser).ID)
+	}
+}
+
+// Observe adds users to the local index, e.g. from a leaderboard, so they can be found
+// by short or misspelled queries.
+func (s *UserSearch) Observe(users ...AutocompleteUser) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, user := range users {
+		s.index(user)
+	}
+}
+
+// ObserveLeaderboard adds every user of a leaderboard to the local index.
+func (s *UserSearch) ObserveLeaderboard(entries []LeaderboardEntry) {
+	users := make([]AutocompleteUser, 0, len(entries))
+	for _, entry := range entries {
+		users = append(users, AutocompleteUser{ID: entry.ID, Username: entry.Username})
+	}
+	s.Observe(users...)
+}
+
+// autocomplete returns the autocomplete response for a term, from the cache if possible.
+// Responses are cached under the exact term sent, since the server may answer differently
+// for terms that only differ in case or accents.
+func (s *UserSearch) autocomplete(ctx context.Context, term string) ([]AutocompleteUser, error) {
+	s.mu.Lock()
+	if elem, ok := s.cache[term]; ok {
+		entry := elem.Value.(*searchEntry)
+		if s.ttl <= 0 || time.Since(entry.fetched) < s.ttl {
+			s.lru.MoveToFront(elem)
+			for _, user := range entry.users {
+				s.index(user)
+			}
+			s.mu.Unlock()
+			return entry.users, nil
+		}
+		s.lru.Remove(elem)
+		delete(s.cache, term)
+	}
+	s.mu.Unlock()
+
+	users, err := s.client.SearchUsersContext(ctx, term)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.cache[term]; !ok {
+		s.cache[term] = s.lru.PushFront(&searchEntry{key: term, users: users, fetched: time.Now()})
+		for s.lru.Len() > s.size {
+			oldest := s.lru.Back()
+			s.lru.Remove(oldest)
+			delete(s.cache, oldest.Value.(*searchEntry).key)
+		}
+	}
+	for _, user := range users {
+		s.index(user)
+	}
+	return users, nil
+}
+
+// Search finds users whose username matches the query, best matches first: exact matches,
+// then prefix matches, then fuzzy matches by increasing distance, each group sorted by username.
+// Queries shorter than the autocomplete minimum are answered from the local index only.
+func (s *UserSearch) Search(ctx context.Context, query string, opts SearchOptions) (*SearchResults, error) {
+	q := normalizeName(query)
+	results := &SearchResults{Query: query}
+	if q == "" {
+		return results, nil
+	}
+
+	if utf8.RuneCountInString(q) >= minAutocompleteLength {
+		if _, err := s.autocomplete(ctx, strings.TrimSpace(query)); err != nil {
+			return nil, err
+		}
+	}
+
+	s.mu.Lock()
+	candidates := make([]AutocompleteUser, 0, s.users.Len())
+	for elem := s.users.Front(); elem != nil; elem = elem.Next() {
+		candidates = append(candidates, elem.Value.(AutocompleteUser))
+	}
+	s.mu.Unlock()
+
+	maxEdits := max(1, utf8.RuneCountInString(q)/3)
+	var ranked []SearchResult
+	for _, user := range candidates {
+		name := normalizeName(user.Username)
+		switch {
+		case name == q:
+			ranked = append(ranked, SearchResult{AutocompleteUser: user, Match: SearchExact})
+		case strings.HasPrefix(name, q):
+			ranked = append(ranked, SearchResult{AutocompleteUser: user, Match: SearchPrefix})
+		default:
+			// Compare against the start of the name too, so that typos in a prefix still match.
+			d := levenshtein(q, name)
+			if r := []rune(name); len(r) > len([]rune(q)) {
+				d = min(d, levenshtein(q, string(r[:len([]rune(q))])))
+			}
+			if d <= maxEdits {
+				ranked = append(ranked, SearchResult{AutocompleteUser: user, Match: SearchFuzzy, Distance: d})
+			}
+		}
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.Match != b.Match {
+			return a.Match < b.Match
+		}
+		if a.Distance != b.Distance {
+			return a.Distance < b.Distance
+		}
+		return strings.ToLower(a.Username) < strings.ToLower(b.Username)
+	})
+
+	results.Total = len(ranked)
+	ranked = ranked[min(max(opts.Offset, 0), len(ranked)):]
+	if opts.Limit > 0 {
+		ranked = ranked[:min(opts.Limit, len(ranked))]
+	}
+	results.Results = ranked
+
+	if opts.Profiles && len(ranked) > 0 {
+		usernames := make([]string, len(ranked))
+		for i, r := range ranked {
+			usernames[i] = r.Username
+		}
+		for i, result := range s.client.GetPlayersInfo(ctx, usernames) {
+			if result.Err != nil {
+				ranked[i].ProfileErr = result.Err
+				continue
+			}
+			user := result.Info.User
+			ranked[i].Profile = &user
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// Lookup returns the user whose username is exactly the given one, ignoring case and accents.
+func (s *UserSearch) Lookup(ctx context.Context, username string) (AutocompleteUser, bool, error) {
+	results, err := s.Search(ctx, username, SearchOptions{Limit: 1})
+	if err != nil {
+		return AutocompleteUser{}, false, err
+	}
+	if len(results.Results) == 0 || results.Results[0].Match != SearchExact {
+		return AutocompleteUser{}, false, nil
+	}
+	return results.Results[0].AutocompleteUser, true, nil
+}
+
+// Purge empties the query cache. The local index of known users is kept.
+func (s *UserSearch) Purge() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lru.Init()
+	s.cache = make(map[string]*list.Element)
+}
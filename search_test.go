@@ -0,0 +1,86 @@
+package wolfyclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// newFakeSearchServer serves autocomplete results for users and their profiles, counting
+// the autocomplete requests per term. Profiles of usernames listed in failing answer 503.
+func newFakeSearchServer(t *testing.T, users []AutocompleteUser, failing ...string) (*Client, map[string]int) {
+	t.Helper()
+
+	var mu sync.Mutex
+	searches := make(map[string]int)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/social/autocomplete/", func(w http.ResponseWriter, r *http.Request) {
+		term, _ := url.PathUnescape(strings.TrimPrefix(r.URL.Path, "/social/autocomplete/"))
+		mu.Lock()
+		searches[term]++
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(users)
+	})
+	mux.HandleFunc("/leaderboard/player/", func(w http.ResponseWriter, r *http.Request) {
+		username := strings.TrimPrefix(r.URL.Path, "/leaderboard/player/")
+		for _, f := range failing {
+			if f == username {
+				http.Error(w, "unavailable", http.StatusServiceUnavailable)
+				return
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(PlayerInfoResponse{User: PlayerUser{Username: username, Elo: 1000}})
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	base, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Client{baseURL: base, httpClient: server.Client()}, searches
+}
+
+func TestUserSearchCachesByTermSent(t *testing.T) {
+	client, searches := newFakeSearchServer(t, []AutocompleteUser{{ID: "1", Username: "Élodie"}})
+	s := NewUserSearch(client, 0, 0)
+
+	for _, query := range []string{"Élo", "elo", " elo "} {
+		if _, err := s.Search(context.Background(), query, SearchOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if searches["Élo"] != 1 || searches["elo"] != 1 {
+		t.Errorf("autocomplete requests = %v, want one per distinct term", searches)
+	}
+}
+
+func TestUserSearchProfileErrors(t *testing.T) {
+	users := []AutocompleteUser{{ID: "1", Username: "alice"}, {ID: "2", Username: "alicia"}}
+	client, _ := newFakeSearchServer(t, users, "alicia")
+	s := NewUserSearch(client, 0, 0)
+
+	results, err := s.Search(context.Background(), "ali", SearchOptions{Profiles: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range results.Results {
+		switch r.Username {
+		case "alice":
+			if r.Profile == nil || r.ProfileErr != nil {
+				t.Errorf("alice: profile %v, error %v", r.Profile, r.ProfileErr)
+			}
+		case "alicia":
+			if r.Profile != nil || r.ProfileErr == nil {
+				t.Errorf("alicia: profile %v, error %v; want an error", r.Profile, r.ProfileErr)
+			}
+		}
+	}
+}
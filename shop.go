@@ -1,6 +1,9 @@
 package wolfyclient
 
-import "io"
+import (
+	"io"
+	"time"
+)
 
 // CollectDailyItem attempts to claim the free daily item from the shop.
 func (c *Client) CollectDailyItem() (string, error) {
@@ -9,7 +12,7 @@ func (c *Client) CollectDailyItem() (string, error) {
 		return "", err
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.send(req)
 	if err != nil {
 		return "", err
 	}
@@ -80,3 +83,19 @@ func (c *Client) GetMoonOffers() ([]MoonOffer, error) {
 	}
 	return moonOffers, nil
 }
+
+// Active reports whether the drop is currently running.
+func (d *CurrentDrop) Active() bool {
+	now := time.Now()
+	return !now.Before(d.Start.Time) && now.Before(d.End.Time)
+}
+
+// Remaining returns how long the drop stays available, or zero if it has ended.
+func (d *CurrentDrop) Remaining() time.Duration {
+	return max(time.Until(d.End.Time), 0)
+}
+
+// ExpiresIn returns how long until this set of daily offers rotates, or zero if it already has.
+func (o *DailyOfferSet) ExpiresIn() time.Duration {
+	return max(time.Until(o.End.Time), 0)
+}
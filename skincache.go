@@ -0,0 +1,402 @@
+package wolfyclient
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// SkinCacheKey identifies a single rendered skin image.
+// A user's render only changes when their skin version changes, so the version
+// is part of the key and old renders simply stop being requested.
+type SkinCacheKey struct {
+	UserID      string
+	SkinVersion string
+	Format      string
+	Profile     string
+	Size        string
+}
+
+// String returns a stable textual form of the key, used for hashing.
+func (k SkinCacheKey) String() string {
+	return strings.Join([]string{k.UserID, k.SkinVersion, k.Format, k.Profile, k.Size}, "|")
+}
+
+// SkinStore is a storage backend for rendered skins.
+// Implementations must be safe for concurrent use.
+type SkinStore interface {
+	// Get returns the cached image for the key, if present.
+	Get(key SkinCacheKey) ([]byte, bool, error)
+	// Put stores the image for the key.
+	Put(key SkinCacheKey, data []byte) error
+	// DeleteUser drops every render cached for the given user.
+	DeleteUser(userID string) error
+}
+
+// skinState is the last known skin identity of a user.
+type skinState struct {
+	version string
+	slotID  string
+}
+
+// SkinCache caches skin renders in a SkinStore and keeps track of every user's
+// current skin version so that stale renders are invalidated automatically.
+type SkinCache struct {
+	mu    sync.Mutex
+	store SkinStore
+	users map[string]skinState
+}
+
+// NewSkinCache creates a skin cache backed by the given store.
+func NewSkinCache(store SkinStore) *SkinCache {
+	return &SkinCache{
+		store: store,
+		users: make(map[string]skinState),
+	}
+}
+
+// Observe records the skin version and slot of a user.
+// If either changed since the last observation, every cached render for that user is dropped.
+func (s *SkinCache) Observe(userID, skinVersion, slotID string) error {
+	if userID == "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	previous, known := s.users[userID]
+	current := skinState{version: skinVersion, slotID: slotID}
+	s.users[userID] = current
+	s.mu.Unlock()
+
+	if known && previous != current {
+		return s.store.DeleteUser(userID)
+	}
+	return nil
+}
+
+// Version returns the last observed skin version for a user.
+func (s *SkinCache) Version(userID string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.users[userID]
+	return state.version, ok
+}
+
+// Get returns a cached render for the key.
+func (s *SkinCache) Get(key SkinCacheKey) ([]byte, bool, error) {
+	return s.store.Get(key)
+}
+
+// Put stores a render for the key.
+func (s *SkinCache) Put(key SkinCacheKey, data []byte) error {
+	return s.store.Put(key, data)
+}
+
+// skinStorePruner is implemented by stores that can drop renders of outdated skin versions.
+type skinStorePruner interface {
+	Prune(current func(userID string) (version string, ok bool)) error
+}
+
+// Prune drops the renders cached for skin versions that are no longer current, as far as
+// this cache has observed, if the store supports it. DiskSkinStore does, which keeps renders
+// of old versions from piling up across restarts.
+func (s *SkinCache) Prune() error {
+	pruner, ok := s.store.(skinStorePruner)
+	if !ok {
+		return nil
+	}
+	return pruner.Prune(s.Version)
+}
+
+// Invalidate forgets a user's skin version and drops all of their cached renders.
+func (s *SkinCache) Invalidate(userID string) error {
+	s.mu.Lock()
+	delete(s.users, userID)
+	s.mu.Unlock()
+	return s.store.DeleteUser(userID)
+}
+
+// --- Memory Backend ---
+
+// memorySkinEntry is a single element of the LRU list.
+type memorySkinEntry struct {
+	key  SkinCacheKey
+	data []byte
+}
+
+// MemorySkinStore is an in-memory SkinStore that evicts the least recently used render
+// once it holds more than its capacity.
+type MemorySkinStore struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	entries  map[SkinCacheKey]*list.Element
+}
+
+// NewMemorySkinStore creates an LRU store holding at most capacity renders.
+// A capacity of zero or less means the store is unbounded.
+func NewMemorySkinStore(capacity int) *MemorySkinStore {
+	return &MemorySkinStore{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[SkinCacheKey]*list.Element),
+	}
+}
+
+// Get returns the cached image and marks it as recently used.
+func (m *MemorySkinStore) Get(key SkinCacheKey) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, ok := m.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	m.order.MoveToFront(elem)
+	return elem.Value.(*memorySkinEntry).data, true, nil
+}
+
+// Put stores the image, evicting the oldest entries if the store is full.
+func (m *MemorySkinStore) Put(key SkinCacheKey, data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if elem, ok := m.entries[key]; ok {
+		elem.Value.(*memorySkinEntry).data = data
+		m.order.MoveToFront(elem)
+		return nil
+	}
+
+	m.entries[key] = m.order.PushFront(&memorySkinEntry{key: key, data: data})
+	for m.capacity > 0 && m.order.Len() > m.capacity {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memorySkinEntry).key)
+	}
+	return nil
+}
+
+// DeleteUser drops every render cached for the given user.
+func (m *MemorySkinStore) DeleteUser(userID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key, elem := range m.entries {
+		if key.UserID == userID {
+			m.order.Remove(elem)
+			delete(m.entries, key)
+		}
+	}
+	return nil
+}
+
+// Len returns the number of renders currently held.
+func (m *MemorySkinStore) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.order.Len()
+}
+
+// --- Disk Backend ---
+
+// DiskSkinStore is a content-addressed SkinStore living in a directory.
+// Image bytes are written once under objects/<sha256 of content>, and each key
+// is a small reference file under refs/<sha256 of user ID>/ pointing at its object, so
+// identical renders (e.g. the same skin in two sizes that render the same) share storage.
+// References also record the user and skin version they were cached for, so that Prune
+// can drop renders of outdated versions.
+type DiskSkinStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewDiskSkinStore creates a disk store rooted at dir, creating it if needed.
+func NewDiskSkinStore(dir string) (*DiskSkinStore, error) {
+	for _, sub := range []string{"objects", "refs"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			return nil, err
+		}
+	}
+	return &DiskSkinStore{dir: dir}, nil
+}
+
+// userDir names the user's reference directory after a hash of the ID, so that no user ID
+// (e.g. "..") can point outside of refs/.
+func (d *DiskSkinStore) userDir(userID string) string {
+	sum := sha256.Sum256([]byte(userID))
+	return filepath.Join(d.dir, "refs", hex.EncodeToString(sum[:]))
+}
+
+func (d *DiskSkinStore) refPath(key SkinCacheKey) string {
+	sum := sha256.Sum256([]byte(key.String()))
+	return filepath.Join(d.userDir(key.UserID), hex.EncodeToString(sum[:]))
+}
+
+// objectPath returns the path of an object. The hash must have been checked with validObjectHash.
+func (d *DiskSkinStore) objectPath(hash string) string {
+	return filepath.Join(d.dir, "objects", hash[:2], hash)
+}
+
+// validObjectHash reports whether a reference points at a well-formed object hash.
+func validObjectHash(hash string) bool {
+	if len(hash) != 2*sha256.Size {
+		return false
+	}
+	_, err := hex.DecodeString(hash)
+	return err == nil
+}
+
+// diskSkinRef is the content of a reference file.
+type diskSkinRef struct {
+	Object      string `json:"object"`
+	UserID      string `json:"userId"`
+	SkinVersion string `json:"skinVersion"`
+}
+
+// readRef reads a reference file. It reports whether the reference is usable:
+// empty, truncated or otherwise corrupted references are not.
+func readRef(path string) (diskSkinRef, bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return diskSkinRef{}, false, err
+	}
+	var ref diskSkinRef
+	if err := json.Unmarshal(data, &ref); err != nil {
+		// Older references hold nothing but the object hash.
+		ref = diskSkinRef{Object: strings.TrimSpace(string(data))}
+	}
+	return ref, validObjectHash(ref.Object), nil
+}
+
+// Get returns the cached image for the key, if present.
+func (d *DiskSkinStore) Get(key SkinCacheKey) ([]byte, bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	ref, ok, err := readRef(d.refPath(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if !ok {
+		// A corrupted reference is a miss; the next Put overwrites it.
+		return nil, false, nil
+	}
+
+	data, err := os.ReadFile(d.objectPath(ref.Object))
+	if errors.Is(err, fs.ErrNotExist) {
+		// The object was pruned from under the reference; treat it as a miss.
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return data, true, nil
+}
+
+// Put stores the image content and points the key's reference at it.
+func (d *DiskSkinStore) Put(key SkinCacheKey, data []byte) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+
+	objectPath := d.objectPath(hash)
+	if _, err := os.Stat(objectPath); errors.Is(err, fs.ErrNotExist) {
+		if err := writeFileAtomic(objectPath, data); err != nil {
+			return err
+		}
+	}
+
+	ref, err := json.Marshal(diskSkinRef{Object: hash, UserID: key.UserID, SkinVersion: key.SkinVersion})
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(d.refPath(key), ref)
+}
+
+// DeleteUser drops every reference held for the given user.
+// The underlying objects are kept until Prune is called.
+func (d *DiskSkinStore) DeleteUser(userID string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return os.RemoveAll(d.userDir(userID))
+}
+
+// Prune removes corrupted references and every object that is no longer referenced by any key.
+// If current is not nil, it is asked for the current skin version of each user with cached
+// renders, and references to other versions are removed first; users it does not know are kept.
+// SkinCache.Prune passes the versions it has observed.
+func (d *DiskSkinStore) Prune(current func(userID string) (version string, ok bool)) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	// 1. Drop stale and corrupted references, and collect every object hash still referenced.
+	live := make(map[string]bool)
+	err := filepath.WalkDir(filepath.Join(d.dir, "refs"), func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		ref, ok, err := readRef(path)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return os.Remove(path)
+		}
+		if current != nil && ref.UserID != "" {
+			if version, known := current(ref.UserID); known && version != ref.SkinVersion {
+				return os.Remove(path)
+			}
+		}
+		live[ref.Object] = true
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// 2. Delete every object that is not in the live set.
+	return filepath.WalkDir(filepath.Join(d.dir, "objects"), func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		if !live[entry.Name()] {
+			return os.Remove(path)
+		}
+		return nil
+	})
+}
+
+// writeFileAtomic writes data to a temporary file and renames it into place,
+// so readers never observe a partially written file.
+func writeFileAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
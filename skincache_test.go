@@ -0,0 +1,62 @@
+package wolfyclient
+
+import (
+	"os"
+	"testing"
+)
+
+func TestDiskSkinStoreCorruptedRef(t *testing.T) {
+	store, err := NewDiskSkinStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := SkinCacheKey{UserID: "u1", SkinVersion: "v1", Format: "png"}
+
+	for _, content := range []string{"", "ab", "../../../../etc/passwd", `{"object":"zz"}`} {
+		if err := writeFileAtomic(store.refPath(key), []byte(content)); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok, err := store.Get(key); ok || err != nil {
+			t.Errorf("Get with ref %q = %v, %v; want a miss", content, ok, err)
+		}
+	}
+
+	if err := store.Prune(nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(store.refPath(key)); !os.IsNotExist(err) {
+		t.Errorf("Prune kept a corrupted reference: %v", err)
+	}
+}
+
+func TestSkinCachePruneStaleVersions(t *testing.T) {
+	store, err := NewDiskSkinStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := SkinCacheKey{UserID: "u1", SkinVersion: "v1", Format: "png"}
+	current := SkinCacheKey{UserID: "u1", SkinVersion: "v2", Format: "png"}
+	other := SkinCacheKey{UserID: "u2", SkinVersion: "v1", Format: "png"}
+	for key, data := range map[SkinCacheKey]string{old: "old", current: "current", other: "other"} {
+		if err := store.Put(key, []byte(data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A fresh cache, as after a restart, that has only seen u1's new version.
+	cache := NewSkinCache(store)
+	cache.users["u1"] = skinState{version: "v2"}
+	if err := cache.Prune(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok, _ := store.Get(old); ok {
+		t.Errorf("render of an outdated version survived Prune")
+	}
+	if data, ok, _ := store.Get(current); !ok || string(data) != "current" {
+		t.Errorf("render of the current version = %q, %v", data, ok)
+	}
+	if _, ok, _ := store.Get(other); !ok {
+		t.Errorf("render of a user the cache never observed was pruned")
+	}
+}
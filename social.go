@@ -1,6 +1,7 @@
 package wolfyclient
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 )
@@ -66,6 +67,57 @@ func (c *Client) RemoveFriend(userID string) (*MessageResponse, error) {
 	return &resp, nil
 }
 
+// GetFriendRequests retrieves the pending friend requests of the authenticated user,
+// both the incoming ones waiting for an answer and the outgoing ones not yet answered.
+func (c *Client) GetFriendRequests() (*FriendRequests, error) {
+	req, err := c.newRequest("GET", "/social/requests", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json, text/plain, */*")
+	req.Header.Set("Referer", "https://wolfy.net/fr/play")
+
+	var requests FriendRequests
+	if err := c.do(req, &requests); err != nil {
+		return nil, err
+	}
+	return &requests, nil
+}
+
+// AcceptFriendRequest accepts an incoming friend request from the specified user ID.
+func (c *Client) AcceptFriendRequest(userID string) (*MessageResponse, error) {
+	return c.doSocialAction(fmt.Sprintf("/social/accept/%s", userID))
+}
+
+// DeclineFriendRequest declines an incoming friend request from the specified user ID.
+func (c *Client) DeclineFriendRequest(userID string) (*MessageResponse, error) {
+	return c.doSocialAction(fmt.Sprintf("/social/decline/%s", userID))
+}
+
+// CancelFriendRequest withdraws a friend request previously sent to the specified user ID.
+func (c *Client) CancelFriendRequest(userID string) (*MessageResponse, error) {
+	return c.doSocialAction(fmt.Sprintf("/social/cancel/%s", userID))
+}
+
+// doSocialAction sends an empty POST to a social endpoint with the headers the browser uses,
+// and decodes the message returned.
+func (c *Client) doSocialAction(path string) (*MessageResponse, error) {
+	req, err := c.newRequest("POST", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json, text/plain, */*")
+	req.Header.Set("Referer", "https://wolfy.net/fr/play")
+
+	var resp MessageResponse
+	if err := c.do(req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // GetFriendLeaderboard retrieves the leaderboard of the authenticated user's friends,
 // returning a slice of users with their rank and summary information.
 func (c *Client) GetFriendLeaderboard() ([]LeaderboardEntry, error) {
@@ -78,6 +130,9 @@ func (c *Client) GetFriendLeaderboard() ([]LeaderboardEntry, error) {
 	if err := c.do(req, &friendLeaderboard); err != nil {
 		return nil, err
 	}
+	for _, entry := range friendLeaderboard {
+		c.observeSkin(entry.ID, entry.SkinVersion, entry.SlotID)
+	}
 	return friendLeaderboard, nil
 }
 
@@ -85,6 +140,12 @@ func (c *Client) GetFriendLeaderboard() ([]LeaderboardEntry, error) {
 // It searches for users whose usernames match the given search term and returns
 // a list of matching user results with their IDs and usernames.
 func (c *Client) SearchUsers(searchTerm string) ([]AutocompleteUser, error) {
+	return c.SearchUsersContext(context.Background(), searchTerm)
+}
+
+// SearchUsersContext is like SearchUsers, but the context can cancel the request
+// or the wait for the rate limiter.
+func (c *Client) SearchUsersContext(ctx context.Context, searchTerm string) ([]AutocompleteUser, error) {
 	// URL encode the search term to handle spaces and special characters
 	encodedTerm := url.QueryEscape(searchTerm)
 	path := fmt.Sprintf("/social/autocomplete/%s", encodedTerm)
@@ -99,7 +160,7 @@ func (c *Client) SearchUsers(searchTerm string) ([]AutocompleteUser, error) {
 	req.Header.Set("Referer", "https://wolfy.net/fr/play")
 
 	var searchResults []AutocompleteUser
-	if err := c.do(req, &searchResults); err != nil {
+	if err := c.do(req.WithContext(ctx), &searchResults); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,341 @@
+package wolfyclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LeaderboardSnapshot is the friend leaderboard as it was at a point in time.
+// Entries keep the order the server returned them in, which is the leaderboard ordering.
+type LeaderboardSnapshot struct {
+	TakenAt time.Time          `json:"takenAt"`
+	Entries []LeaderboardEntry `json:"entries"`
+}
+
+// Position returns the 1-based position of a user in the snapshot, or 0 if they are absent.
+func (s LeaderboardSnapshot) Position(userID string) int {
+	for i, entry := range s.Entries {
+		if entry.ID == userID {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// SnapshotFriendLeaderboard fetches the friend leaderboard and timestamps it.
+func (c *Client) SnapshotFriendLeaderboard() (LeaderboardSnapshot, error) {
+	entries, err := c.GetFriendLeaderboard()
+	if err != nil {
+		return LeaderboardSnapshot{}, err
+	}
+	return LeaderboardSnapshot{TakenAt: time.Now(), Entries: entries}, nil
+}
+
+// SnapshotArchive keeps friend leaderboard snapshots, oldest first, optionally backed by a
+// JSON file. It is safe for concurrent use.
+type SnapshotArchive struct {
+	mu        sync.Mutex
+	path      string
+	snapshots []LeaderboardSnapshot
+}
+
+// NewSnapshotArchive creates an in-memory archive.
+func NewSnapshotArchive() *SnapshotArchive {
+	return &SnapshotArchive{}
+}
+
+// OpenSnapshotArchive loads an archive from a JSON file, or creates an empty one if the file
+// does not exist yet. Call Save to write it back.
+func OpenSnapshotArchive(path string) (*SnapshotArchive, error) {
+	archive := &SnapshotArchive{path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return archive, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &archive.snapshots); err != nil {
+		return nil, fmt.Errorf("could not read snapshot archive '%s': %w", path, err)
+	}
+	sort.SliceStable(archive.snapshots, func(i, j int) bool {
+		return archive.snapshots[i].TakenAt.Before(archive.snapshots[j].TakenAt)
+	})
+	return archive, nil
+}
+
+// Add records a snapshot, keeping the archive ordered by time.
+func (a *SnapshotArchive) Add(snapshot LeaderboardSnapshot) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	i := sort.Search(len(a.snapshots), func(i int) bool { return a.snapshots[i].TakenAt.After(snapshot.TakenAt) })
+	a.snapshots = append(a.snapshots, LeaderboardSnapshot{})
+	copy(a.snapshots[i+1:], a.snapshots[i:])
+	a.snapshots[i] = snapshot
+}
+
+// Snapshots returns every snapshot, oldest first.
+func (a *SnapshotArchive) Snapshots() []LeaderboardSnapshot {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]LeaderboardSnapshot(nil), a.snapshots...)
+}
+
+// Latest returns the most recent snapshot.
+func (a *SnapshotArchive) Latest() (LeaderboardSnapshot, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.snapshots) == 0 {
+		return LeaderboardSnapshot{}, false
+	}
+	return a.snapshots[len(a.snapshots)-1], true
+}
+
+// Before returns the most recent snapshot taken at or before t, e.g. to report on a whole week.
+func (a *SnapshotArchive) Before(t time.Time) (LeaderboardSnapshot, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for i := len(a.snapshots) - 1; i >= 0; i-- {
+		if !a.snapshots[i].TakenAt.After(t) {
+			return a.snapshots[i], true
+		}
+	}
+	return LeaderboardSnapshot{}, false
+}
+
+// Save writes the archive to the file it was opened from.
+// It does nothing for archives created with NewSnapshotArchive.
+func (a *SnapshotArchive) Save() error {
+	if a.path == "" {
+		return nil
+	}
+
+	a.mu.Lock()
+	data, err := json.Marshal(a.snapshots)
+	a.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(a.path, data)
+}
+
+// SnapshotAndCompare takes a new snapshot of the friend leaderboard, records it in the archive
+// and returns the movement since the previous one. The report is nil on the first snapshot.
+// The archive is only changed in memory: call archive.Save to persist the new snapshot.
+func (c *Client) SnapshotAndCompare(archive *SnapshotArchive) (*MovementReport, error) {
+	previous, hasPrevious := archive.Latest()
+	current, err := c.SnapshotFriendLeaderboard()
+	if err != nil {
+		return nil, err
+	}
+	archive.Add(current)
+	if !hasPrevious {
+		return nil, nil
+	}
+	return CompareSnapshots(previous, current), nil
+}
+
+// --- Movement report ---
+
+// Movement is how one player's standing changed between two snapshots.
+type Movement struct {
+	ID          string `json:"id"`
+	Username    string `json:"username"`
+	From        int    `json:"from"`      // Previous position, 0 for new players.
+	To          int    `json:"to"`        // Current position, 0 for players who left.
+	EloGained   int    `json:"eloGained"` // Negative for losses.
+	XPGained    int    `json:"xpGained"`
+	GamesPlayed int    `json:"gamesPlayed"` // Games played between the two snapshots.
+	Elo         int    `json:"elo"`
+}
+
+// Places returns the number of positions gained, negative when the player went down.
+// It is 0 for players who joined or left the leaderboard.
+func (m Movement) Places() int {
+	if m.From == 0 || m.To == 0 {
+		return 0
+	}
+	return m.From - m.To
+}
+
+// MovementReport summarizes the changes between two friend leaderboard snapshots.
+type MovementReport struct {
+	From      time.Time  `json:"from"`
+	To        time.Time  `json:"to"`
+	Movements []Movement `json:"movements"` // Players in both snapshots, by current position.
+	Joined    []Movement `json:"joined"`    // New friends, by current position.
+	Left      []Movement `json:"left"`      // Players no longer on the leaderboard, by previous position.
+}
+
+// CompareSnapshots reports the movement between an older and a newer snapshot.
+func CompareSnapshots(older, newer LeaderboardSnapshot) *MovementReport {
+	report := &MovementReport{From: older.TakenAt, To: newer.TakenAt}
+
+	before := make(map[string]int, len(older.Entries))
+	for i, entry := range older.Entries {
+		before[entry.ID] = i
+	}
+	seen := make(map[string]bool, len(newer.Entries))
+
+	for i, entry := range newer.Entries {
+		seen[entry.ID] = true
+		m := Movement{ID: entry.ID, Username: entry.Username, To: i + 1, Elo: entry.Elo}
+
+		j, ok := before[entry.ID]
+		if !ok {
+			report.Joined = append(report.Joined, m)
+			continue
+		}
+		old := older.Entries[j]
+		m.From = j + 1
+		m.EloGained = entry.Elo - old.Elo
+		m.XPGained = entry.XP - old.XP
+		m.GamesPlayed = entry.GamePlayed - old.GamePlayed
+		report.Movements = append(report.Movements, m)
+	}
+
+	for i, entry := range older.Entries {
+		if !seen[entry.ID] {
+			report.Left = append(report.Left, Movement{ID: entry.ID, Username: entry.Username, From: i + 1, Elo: entry.Elo})
+		}
+	}
+	return report
+}
+
+// Climbers returns the players who gained positions, biggest climb first.
+func (r *MovementReport) Climbers() []Movement {
+	return r.moved(func(m Movement) bool { return m.Places() > 0 })
+}
+
+// Fallers returns the players who lost positions, biggest fall first.
+func (r *MovementReport) Fallers() []Movement {
+	return r.moved(func(m Movement) bool { return m.Places() < 0 })
+}
+
+func (r *MovementReport) moved(keep func(Movement) bool) []Movement {
+	var moved []Movement
+	for _, m := range r.Movements {
+		if keep(m) {
+			moved = append(moved, m)
+		}
+	}
+	sort.SliceStable(moved, func(i, j int) bool {
+		return abs(moved[i].Places()) > abs(moved[j].Places())
+	})
+	return moved
+}
+
+// TopGainers returns up to n players sorted by Elo gained, ignoring those who gained nothing.
+func (r *MovementReport) TopGainers(n int) []Movement {
+	var gainers []Movement
+	for _, m := range r.Movements {
+		if m.EloGained > 0 {
+			gainers = append(gainers, m)
+		}
+	}
+	sort.SliceStable(gainers, func(i, j int) bool { return gainers[i].EloGained > gainers[j].EloGained })
+	return gainers[:min(max(n, 0), len(gainers))]
+}
+
+// GamesPlayed returns the total number of games played by the players of the report.
+func (r *MovementReport) GamesPlayed() int {
+	total := 0
+	for _, m := range r.Movements {
+		total += m.GamesPlayed
+	}
+	return total
+}
+
+// Text renders the report as plain text.
+func (r *MovementReport) Text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Leaderboard from %s to %s\n", r.From.Format(time.DateOnly), r.To.Format(time.DateOnly))
+	fmt.Fprintf(&b, "%d games played\n", r.GamesPlayed())
+	for _, m := range r.Movements {
+		fmt.Fprintf(&b, "%3d. %-20s %6s  %+5d Elo  %+6d XP  %d games\n",
+			m.To, m.Username, arrow(m.Places()), m.EloGained, m.XPGained, m.GamesPlayed)
+	}
+	for _, m := range r.Joined {
+		fmt.Fprintf(&b, "+ %s joined at #%d (%d Elo)\n", m.Username, m.To, m.Elo)
+	}
+	for _, m := range r.Left {
+		fmt.Fprintf(&b, "- %s left (was #%d)\n", m.Username, m.From)
+	}
+	return b.String()
+}
+
+// Markdown renders the report as a Markdown table, suitable for a forum or Discord post.
+func (r *MovementReport) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## Leaderboard, %s to %s\n\n", r.From.Format(time.DateOnly), r.To.Format(time.DateOnly))
+	b.WriteString("| # | Player | Move | Elo | XP | Games |\n")
+	b.WriteString("|--:|--------|-----:|----:|---:|------:|\n")
+	for _, m := range r.Movements {
+		fmt.Fprintf(&b, "| %d | %s | %s | %+d | %+d | %d |\n",
+			m.To, markdownEscape(m.Username), arrow(m.Places()), m.EloGained, m.XPGained, m.GamesPlayed)
+	}
+
+	if len(r.Joined) > 0 {
+		b.WriteString("\n**New friends:** ")
+		for i, m := range r.Joined {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			fmt.Fprintf(&b, "%s (#%d)", markdownEscape(m.Username), m.To)
+		}
+		b.WriteString("\n")
+	}
+	if len(r.Left) > 0 {
+		b.WriteString("\n**Left:** ")
+		for i, m := range r.Left {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			b.WriteString(markdownEscape(m.Username))
+		}
+		b.WriteString("\n")
+	}
+	fmt.Fprintf(&b, "\n%d games played this period.\n", r.GamesPlayed())
+	return b.String()
+}
+
+// WriteJSON writes the report as JSON.
+func (r *MovementReport) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// arrow renders a change of position, e.g. "▲3", "▼1" or "=".
+func arrow(places int) string {
+	switch {
+	case places > 0:
+		return fmt.Sprintf("▲%d", places)
+	case places < 0:
+		return fmt.Sprintf("▼%d", -places)
+	}
+	return "="
+}
+
+// markdownEscape escapes the characters of a username that Markdown would interpret.
+func markdownEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "|", `\|`, "*", `\*`, "_", `\_`, "`", "\\`").Replace(s)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,245 @@
+package wolfyclient
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// StatKey is a key of RoleStats.AdvancedStats.
+type StatKey string
+
+// StatUnit tells how the value of an advanced stat should be read.
+type StatUnit string
+
+// Known stat units.
+const (
+	StatUnitPercentage StatUnit = "percentage" // A rate between 0 and 100.
+	StatUnitCount      StatUnit = "count"      // A total over every game played with the role.
+	StatUnitAverage    StatUnit = "average"    // A per-game average.
+)
+
+// Format renders a stat value according to its unit.
+func (u StatUnit) Format(value float64) string {
+	switch u {
+	case StatUnitPercentage:
+		return strconv.FormatFloat(value, 'f', 1, 64) + "%"
+	case StatUnitCount:
+		return strconv.FormatFloat(value, 'f', 0, 64)
+	}
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
+
+// Advanced stat keys shared by every role.
+const (
+	StatInactivity StatKey = "inactivity"
+	StatDaysAlive  StatKey = "daysAlive"
+	StatMayor      StatKey = "mayor"
+	StatGoodVote   StatKey = "goodVote"
+)
+
+// Role-specific advanced stat keys.
+const (
+	StatSeerThreatSeen     StatKey = "threatSeen"
+	StatWitchHealed        StatKey = "healed"
+	StatWitchGoodPoison    StatKey = "goodPoison"
+	StatHunterGoodShot     StatKey = "goodShot"
+	StatCupidLoversWin     StatKey = "loversWin"
+	StatGuardProtected     StatKey = "protected"
+	StatRavenGoodRaven     StatKey = "goodRaven"
+	StatFoxThreatFound     StatKey = "threatFound"
+	StatWolfInnocentKilled StatKey = "innocentKilled"
+	StatInfectInfected     StatKey = "infected"
+	StatWhiteWolvesKilled  StatKey = "wolvesKilled"
+	StatPyromaniacBurned   StatKey = "burned"
+	StatPiperCharmed       StatKey = "charmed"
+)
+
+// StatKeyInfo describes an advanced stat.
+type StatKeyInfo struct {
+	Key         StatKey
+	Unit        StatUnit
+	Description string
+}
+
+// commonStatKeys apply to every role.
+var commonStatKeys = []StatKeyInfo{
+	{StatInactivity, StatUnitPercentage, "Share of days spent without speaking or voting."},
+	{StatDaysAlive, StatUnitAverage, "Number of days survived per game."},
+	{StatMayor, StatUnitPercentage, "Share of games in which the player was elected mayor."},
+	{StatGoodVote, StatUnitPercentage, "Share of votes cast against a player of the opposing camp."},
+}
+
+var (
+	statKeysMu   sync.RWMutex
+	roleStatKeys = map[Role][]StatKeyInfo{
+		RoleSeer: {
+			{StatSeerThreatSeen, StatUnitAverage, "Number of threats uncovered per game."},
+		},
+		RoleWitch: {
+			{StatWitchHealed, StatUnitAverage, "Number of players saved with the healing potion per game."},
+			{StatWitchGoodPoison, StatUnitPercentage, "Share of poison potions used on a threat."},
+		},
+		RoleHunter: {
+			{StatHunterGoodShot, StatUnitPercentage, "Share of final shots that hit a threat."},
+		},
+		RoleCupid: {
+			{StatCupidLoversWin, StatUnitPercentage, "Share of games won by the lovers."},
+		},
+		RoleGuard: {
+			{StatGuardProtected, StatUnitAverage, "Number of successful protections per game."},
+		},
+		RoleRaven: {
+			{StatRavenGoodRaven, StatUnitPercentage, "Share of marks placed on a threat."},
+		},
+		RoleFox: {
+			{StatFoxThreatFound, StatUnitAverage, "Number of successful sniffs per game."},
+		},
+		RoleWerewolf: {
+			{StatWolfInnocentKilled, StatUnitAverage, "Number of innocents killed per game."},
+		},
+		RoleBigBadWolf: {
+			{StatWolfInnocentKilled, StatUnitAverage, "Number of innocents killed per game."},
+		},
+		RoleInfectFather: {
+			{StatWolfInnocentKilled, StatUnitAverage, "Number of innocents killed per game."},
+			{StatInfectInfected, StatUnitCount, "Number of players infected."},
+		},
+		RoleTalkativeWolf: {
+			{StatWolfInnocentKilled, StatUnitAverage, "Number of innocents killed per game."},
+		},
+		RoleWhiteWerewolf: {
+			{StatWolfInnocentKilled, StatUnitAverage, "Number of innocents killed per game."},
+			{StatWhiteWolvesKilled, StatUnitAverage, "Number of wolves devoured per game."},
+		},
+		RolePyromaniac: {
+			{StatPyromaniacBurned, StatUnitAverage, "Number of players burned per game."},
+		},
+		RolePiper: {
+			{StatPiperCharmed, StatUnitAverage, "Number of players charmed per game."},
+		},
+	}
+)
+
+// RegisterStatKey declares an advanced stat for a role, or replaces its description.
+// Use it to describe metrics added to Wolfy after this library was released.
+func RegisterStatKey(role Role, info StatKeyInfo) {
+	statKeysMu.Lock()
+	defer statKeysMu.Unlock()
+
+	keys := roleStatKeys[role]
+	for i, existing := range keys {
+		if existing.Key == info.Key {
+			keys[i] = info
+			return
+		}
+	}
+	roleStatKeys[role] = append(keys, info)
+}
+
+// StatKeys returns every advanced stat known for a role, common stats first.
+func StatKeys(role Role) []StatKeyInfo {
+	statKeysMu.RLock()
+	defer statKeysMu.RUnlock()
+	keys := append([]StatKeyInfo(nil), commonStatKeys...)
+	return append(keys, roleStatKeys[role]...)
+}
+
+// LookupStatKey returns the description of an advanced stat for a role.
+func LookupStatKey(role Role, key StatKey) (StatKeyInfo, bool) {
+	for _, info := range StatKeys(role) {
+		if info.Key == key {
+			return info, true
+		}
+	}
+	return StatKeyInfo{}, false
+}
+
+// StatValue is an advanced stat value paired with its description.
+// Known is false for keys not registered for the role; their Info only carries the key.
+type StatValue struct {
+	Info  StatKeyInfo
+	Value float64
+	Known bool
+}
+
+// String renders the value according to its unit.
+func (v StatValue) String() string {
+	return v.Info.Unit.Format(v.Value)
+}
+
+// Stat returns the value of an advanced stat.
+func (r RoleStats) Stat(key StatKey) (StatValue, bool) {
+	value, ok := r.AdvancedStats[string(key)]
+	if !ok {
+		return StatValue{}, false
+	}
+	info, known := LookupStatKey(r.ID, key)
+	if !known {
+		info = StatKeyInfo{Key: key, Unit: StatUnitAverage}
+	}
+	return StatValue{Info: info, Value: value, Known: known}, true
+}
+
+// Stats returns every advanced stat of the role, sorted by key.
+func (r RoleStats) Stats() []StatValue {
+	values := make([]StatValue, 0, len(r.AdvancedStats))
+	for key := range r.AdvancedStats {
+		value, _ := r.Stat(StatKey(key))
+		values = append(values, value)
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i].Info.Key < values[j].Info.Key })
+	return values
+}
+
+// UnknownStatKeys returns the keys of AdvancedStats that are not registered for the role, sorted.
+func (r RoleStats) UnknownStatKeys() []StatKey {
+	var unknown []StatKey
+	for key := range r.AdvancedStats {
+		if _, ok := LookupStatKey(r.ID, StatKey(key)); !ok {
+			unknown = append(unknown, StatKey(key))
+		}
+	}
+	sort.Slice(unknown, func(i, j int) bool { return unknown[i] < unknown[j] })
+	return unknown
+}
+
+// UnknownStatKeysError lists the advanced stat keys that are not registered, per role.
+type UnknownStatKeysError struct {
+	Keys map[Role][]StatKey
+}
+
+func (e *UnknownStatKeysError) Error() string {
+	roles := make([]string, 0, len(e.Keys))
+	for role := range e.Keys {
+		roles = append(roles, string(role))
+	}
+	sort.Strings(roles)
+
+	parts := make([]string, len(roles))
+	for i, role := range roles {
+		keys := make([]string, len(e.Keys[Role(role)]))
+		for j, key := range e.Keys[Role(role)] {
+			keys[j] = string(key)
+		}
+		parts[i] = fmt.Sprintf("%s: %s", role, strings.Join(keys, ", "))
+	}
+	return "unknown advanced stat keys (" + strings.Join(parts, "; ") + ")"
+}
+
+// ValidateStatKeys checks every role's advanced stats against the registered keys.
+// It returns an *UnknownStatKeysError if any key is unknown, and nil otherwise.
+func (s PlayerStatistics) ValidateStatKeys() error {
+	unknown := make(map[Role][]StatKey)
+	for _, role := range s.Roles {
+		if keys := role.UnknownStatKeys(); len(keys) > 0 {
+			unknown[role.ID] = keys
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+	return &UnknownStatKeysError{Keys: unknown}
+}
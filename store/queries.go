@@ -0,0 +1,173 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	wolfyclient "github.com/go-lover/go-wolfy"
+)
+
+// ErrNotFound is returned when a requested record is not in the store.
+var ErrNotFound = errors.New("not found in store")
+
+// StatisticsSnapshot is a player's statistics as stored at a given time.
+type StatisticsSnapshot struct {
+	UserID     string
+	TakenAt    time.Time
+	Statistics wolfyclient.PlayerStatistics
+}
+
+// Player returns the stored player with the given ID.
+func (s *Store) Player(ctx context.Context, id string) (*wolfyclient.PlayerUser, error) {
+	return s.queryPlayer(ctx, `SELECT data FROM players WHERE id = ?`, id)
+}
+
+// PlayerByUsername returns the stored player with the given username.
+// If several stored players had that name over time, the most recently updated one wins.
+func (s *Store) PlayerByUsername(ctx context.Context, username string) (*wolfyclient.PlayerUser, error) {
+	return s.queryPlayer(ctx, `SELECT data FROM players WHERE username = ? ORDER BY updated_at DESC LIMIT 1`, username)
+}
+
+func (s *Store) queryPlayer(ctx context.Context, query string, args ...any) (*wolfyclient.PlayerUser, error) {
+	var data string
+	err := s.db.QueryRowContext(ctx, query, args...).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var user wolfyclient.PlayerUser
+	if err := json.Unmarshal([]byte(data), &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Game returns the stored game with the given ID.
+func (s *Store) Game(ctx context.Context, id string) (*wolfyclient.Game, error) {
+	var data string
+	err := s.db.QueryRowContext(ctx, `SELECT data FROM games WHERE id = ?`, id).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var game wolfyclient.Game
+	if err := json.Unmarshal([]byte(data), &game); err != nil {
+		return nil, err
+	}
+	return &game, nil
+}
+
+// GamesBetween returns the stored games created in [from, to), oldest first.
+func (s *Store) GamesBetween(ctx context.Context, from, to time.Time) ([]wolfyclient.Game, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT data FROM games WHERE created_at >= ? AND created_at < ? ORDER BY created_at`,
+		formatTime(from), formatTime(to))
+	if err != nil {
+		return nil, err
+	}
+	return scanJSON[wolfyclient.Game](rows)
+}
+
+// History returns every stored history entry of a player, oldest game first.
+func (s *Store) History(ctx context.Context, userID string) ([]wolfyclient.GameHistoryEntry, error) {
+	return s.queryHistory(ctx, `SELECT data FROM history WHERE user_id = ? ORDER BY created_at`, userID)
+}
+
+// HistoryBetween returns the history entries of a player for games played in [from, to), oldest first.
+func (s *Store) HistoryBetween(ctx context.Context, userID string, from, to time.Time) ([]wolfyclient.GameHistoryEntry, error) {
+	return s.queryHistory(ctx,
+		`SELECT data FROM history WHERE user_id = ? AND created_at >= ? AND created_at < ? ORDER BY created_at`,
+		userID, formatTime(from), formatTime(to))
+}
+
+// HistoryByRole returns the history entries of a player for games played with the given role, oldest first.
+func (s *Store) HistoryByRole(ctx context.Context, userID string, role wolfyclient.Role) ([]wolfyclient.GameHistoryEntry, error) {
+	return s.queryHistory(ctx,
+		`SELECT data FROM history WHERE user_id = ? AND role = ? ORDER BY created_at`,
+		userID, string(role))
+}
+
+// GameParticipants returns every stored history entry for a game, one per known participant.
+func (s *Store) GameParticipants(ctx context.Context, gameID string) ([]wolfyclient.GameHistoryEntry, error) {
+	return s.queryHistory(ctx, `SELECT data FROM history WHERE game_id = ? ORDER BY user_id`, gameID)
+}
+
+func (s *Store) queryHistory(ctx context.Context, query string, args ...any) ([]wolfyclient.GameHistoryEntry, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanJSON[wolfyclient.GameHistoryEntry](rows)
+}
+
+// LatestStatistics returns the most recent statistics snapshot of a player.
+func (s *Store) LatestStatistics(ctx context.Context, userID string) (*StatisticsSnapshot, error) {
+	snapshots, err := s.querySnapshots(ctx,
+		`SELECT user_id, taken_at, data FROM statistics WHERE user_id = ? ORDER BY taken_at DESC LIMIT 1`, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshots) == 0 {
+		return nil, ErrNotFound
+	}
+	return &snapshots[0], nil
+}
+
+// StatisticsHistory returns every statistics snapshot of a player, oldest first.
+func (s *Store) StatisticsHistory(ctx context.Context, userID string) ([]StatisticsSnapshot, error) {
+	return s.querySnapshots(ctx,
+		`SELECT user_id, taken_at, data FROM statistics WHERE user_id = ? ORDER BY taken_at`, userID)
+}
+
+func (s *Store) querySnapshots(ctx context.Context, query string, args ...any) ([]StatisticsSnapshot, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var snapshots []StatisticsSnapshot
+	for rows.Next() {
+		var snapshot StatisticsSnapshot
+		var takenAt, data string
+		if err := rows.Scan(&snapshot.UserID, &takenAt, &data); err != nil {
+			return nil, err
+		}
+		if snapshot.TakenAt, err = time.Parse(timeLayout, takenAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(data), &snapshot.Statistics); err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots, rows.Err()
+}
+
+// scanJSON decodes the single JSON column of every row, then closes the rows.
+func scanJSON[T any](rows *sql.Rows) ([]T, error) {
+	defer rows.Close()
+
+	var results []T
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		var v T
+		if err := json.Unmarshal([]byte(data), &v); err != nil {
+			return nil, err
+		}
+		results = append(results, v)
+	}
+	return results, rows.Err()
+}
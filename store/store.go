@@ -0,0 +1,288 @@
+// Package store persists Wolfy players, games, history and statistics into a SQLite
+// database, so that analytics can run offline instead of re-fetching the same profiles.
+//
+// The package works with any database/sql SQLite driver; import one for its side effects
+// and pass its name to Open, e.g. with modernc.org/sqlite:
+//
+//	import _ "modernc.org/sqlite"
+//
+//	db, err := store.Open("sqlite", "wolfy.db")
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	wolfyclient "github.com/go-lover/go-wolfy"
+)
+
+// timeLayout is the format times are stored in. It has a fixed width and is always UTC,
+// so that stored times sort and compare correctly as text.
+const timeLayout = "2006-01-02T15:04:05.000Z"
+
+// Store is a SQLite-backed store of Wolfy data. It is safe for concurrent use.
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens the database with the given driver and data source, and migrates it to the latest schema.
+func Open(driverName, dataSourceName string) (*Store, error) {
+	db, err := sql.Open(driverName, dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+	s, err := New(context.Background(), db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// New wraps an already opened SQLite database and migrates it to the latest schema.
+func New(ctx context.Context, db *sql.DB) (*Store, error) {
+	s := &Store{db: db}
+	if err := s.migrate(ctx); err != nil {
+		return nil, fmt.Errorf("could not migrate store: %w", err)
+	}
+	return s, nil
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// DB returns the underlying database, for queries not covered by the helpers.
+func (s *Store) DB() *sql.DB {
+	return s.db
+}
+
+// --- Migrations ---
+
+// migrations are applied in order, each exactly once. Never edit a released migration;
+// append a new one instead.
+var migrations = []string{
+	// 1: initial schema.
+	`CREATE TABLE players (
+		id            TEXT PRIMARY KEY,
+		username      TEXT NOT NULL,
+		rank          INTEGER NOT NULL,
+		xp            INTEGER NOT NULL,
+		elo           INTEGER NOT NULL,
+		game_played   INTEGER NOT NULL,
+		data          TEXT NOT NULL,
+		updated_at    TEXT NOT NULL
+	);
+	CREATE INDEX players_username ON players (username);
+
+	CREATE TABLE games (
+		id            TEXT PRIMARY KEY,
+		player_count  INTEGER NOT NULL,
+		lang          TEXT NOT NULL,
+		created_at    TEXT NOT NULL,
+		data          TEXT NOT NULL
+	);
+	CREATE INDEX games_created_at ON games (created_at);
+
+	CREATE TABLE history (
+		user_id       TEXT NOT NULL,
+		game_id       TEXT NOT NULL,
+		role          TEXT NOT NULL,
+		winner        INTEGER NOT NULL,
+		death_type    TEXT,
+		death_day     INTEGER,
+		xp            INTEGER NOT NULL,
+		elo           INTEGER NOT NULL,
+		created_at    TEXT NOT NULL,
+		data          TEXT NOT NULL,
+		PRIMARY KEY (user_id, game_id)
+	);
+	CREATE INDEX history_game ON history (game_id);
+	CREATE INDEX history_user_created_at ON history (user_id, created_at);
+	CREATE INDEX history_user_role ON history (user_id, role);
+
+	CREATE TABLE statistics (
+		user_id       TEXT NOT NULL,
+		taken_at      TEXT NOT NULL,
+		data          TEXT NOT NULL,
+		PRIMARY KEY (user_id, taken_at)
+	);`,
+}
+
+// migrate creates the migration table if needed and applies every pending migration.
+func (s *Store) migrate(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (version INTEGER PRIMARY KEY)`); err != nil {
+		return err
+	}
+
+	var current int
+	if err := s.db.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&current); err != nil {
+		return err
+	}
+
+	for version := current + 1; version <= len(migrations); version++ {
+		err := s.inTx(ctx, func(tx *sql.Tx) error {
+			if _, err := tx.ExecContext(ctx, migrations[version-1]); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, `INSERT INTO schema_migrations (version) VALUES (?)`, version)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("migration %d: %w", version, err)
+		}
+	}
+	return nil
+}
+
+// SchemaVersion returns the version of the latest migration applied to the database.
+func (s *Store) SchemaVersion(ctx context.Context) (int, error) {
+	var version int
+	err := s.db.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version)
+	return version, err
+}
+
+// inTx runs fn in a transaction, committing if it succeeds and rolling back otherwise.
+func (s *Store) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// --- Upserts ---
+
+// SavePlayerInfo stores a whole profile response in one transaction: the player,
+// their history and the games it references, and a snapshot of their statistics.
+func (s *Store) SavePlayerInfo(ctx context.Context, info *wolfyclient.PlayerInfoResponse) error {
+	takenAt := time.Now()
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		if err := upsertPlayer(ctx, tx, info.User); err != nil {
+			return err
+		}
+		for _, entry := range info.History {
+			if err := upsertHistory(ctx, tx, entry); err != nil {
+				return err
+			}
+		}
+		return insertStatistics(ctx, tx, info.User.ID, info.Statistics, takenAt)
+	})
+}
+
+// UpsertPlayer inserts a player or updates the stored copy.
+func (s *Store) UpsertPlayer(ctx context.Context, user wolfyclient.PlayerUser) error {
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		return upsertPlayer(ctx, tx, user)
+	})
+}
+
+// UpsertGame inserts a game or updates the stored copy.
+func (s *Store) UpsertGame(ctx context.Context, game wolfyclient.Game) error {
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		return upsertGame(ctx, tx, game)
+	})
+}
+
+// UpsertHistory inserts history entries, and the games they reference, or updates the stored copies.
+func (s *Store) UpsertHistory(ctx context.Context, entries ...wolfyclient.GameHistoryEntry) error {
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		for _, entry := range entries {
+			if err := upsertHistory(ctx, tx, entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SaveStatistics stores a snapshot of a player's statistics taken at the given time.
+func (s *Store) SaveStatistics(ctx context.Context, userID string, stats wolfyclient.PlayerStatistics, takenAt time.Time) error {
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		return insertStatistics(ctx, tx, userID, stats, takenAt)
+	})
+}
+
+func upsertPlayer(ctx context.Context, tx *sql.Tx, user wolfyclient.PlayerUser) error {
+	data, err := json.Marshal(user)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO players (id, username, rank, xp, elo, game_played, data, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			username = excluded.username, rank = excluded.rank, xp = excluded.xp, elo = excluded.elo,
+			game_played = excluded.game_played, data = excluded.data, updated_at = excluded.updated_at`,
+		user.ID, user.Username, user.Rank, user.XP, user.Elo, user.GamePlayed, string(data), formatTime(time.Now()))
+	return err
+}
+
+func upsertGame(ctx context.Context, tx *sql.Tx, game wolfyclient.Game) error {
+	data, err := json.Marshal(game)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO games (id, player_count, lang, created_at, data)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET
+			player_count = excluded.player_count, lang = excluded.lang,
+			created_at = excluded.created_at, data = excluded.data`,
+		game.ID, game.PlayerCount, game.Lang, formatTime(game.CreatedAt.Time), string(data))
+	return err
+}
+
+func upsertHistory(ctx context.Context, tx *sql.Tx, entry wolfyclient.GameHistoryEntry) error {
+	// Entries embed their game; store it on its own so games can be queried directly.
+	if entry.Game.ID != "" {
+		if err := upsertGame(ctx, tx, entry.Game); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	var deathType, deathDay any
+	if entry.DeathReason != nil {
+		deathType, deathDay = string(entry.DeathReason.Type), entry.DeathReason.DayNumber
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO history (user_id, game_id, role, winner, death_type, death_day, xp, elo, created_at, data)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (user_id, game_id) DO UPDATE SET
+			role = excluded.role, winner = excluded.winner, death_type = excluded.death_type,
+			death_day = excluded.death_day, xp = excluded.xp, elo = excluded.elo,
+			created_at = excluded.created_at, data = excluded.data`,
+		entry.UserID, entry.GameID, string(entry.Role), entry.Winner, deathType, deathDay,
+		entry.XP, entry.Elo, formatTime(entry.CreatedAt.Time), string(data))
+	return err
+}
+
+func insertStatistics(ctx context.Context, tx *sql.Tx, userID string, stats wolfyclient.PlayerStatistics, takenAt time.Time) error {
+	data, err := json.Marshal(stats)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO statistics (user_id, taken_at, data) VALUES (?, ?, ?)
+		ON CONFLICT (user_id, taken_at) DO UPDATE SET data = excluded.data`,
+		userID, formatTime(takenAt), string(data))
+	return err
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeLayout)
+}
@@ -0,0 +1,341 @@
+package wolfyclient
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"io"
+	"sync"
+	"time"
+)
+
+// SkinSnapshot is a single look a player had, captured the first time its skin version was seen.
+type SkinSnapshot struct {
+	UserID      string
+	SkinVersion string
+	ObservedAt  time.Time
+	Image       []byte // Full-body PNG render.
+}
+
+// SkinTimeline records the distinct skin versions observed for players,
+// along with a PNG render of each, so their look can be replayed over time.
+type SkinTimeline struct {
+	client *Client
+	mu     sync.Mutex
+	users  map[string][]SkinSnapshot
+}
+
+// NewSkinTimeline creates a timeline that uses the client to fetch renders.
+func NewSkinTimeline(client *Client) *SkinTimeline {
+	return &SkinTimeline{
+		client: client,
+		users:  make(map[string][]SkinSnapshot),
+	}
+}
+
+// Record captures the player's current look if its skin version has not been recorded yet.
+// It returns true if a new snapshot was added.
+func (t *SkinTimeline) Record(user PlayerUser) (bool, error) {
+	t.mu.Lock()
+	recorded := hasSkinVersion(t.users[user.ID], user.SkinVersion)
+	t.mu.Unlock()
+	if recorded {
+		return false, nil
+	}
+
+	imageData, err := t.client.GetUserSkin(user.ID, SkinFormatPNG, SkinProfileFull, SkinSizeDefault)
+	if err != nil {
+		return false, fmt.Errorf("could not render skin for '%s': %w", user.Username, err)
+	}
+
+	return t.Add(SkinSnapshot{
+		UserID:      user.ID,
+		SkinVersion: user.SkinVersion,
+		ObservedAt:  time.Now(),
+		Image:       imageData,
+	}), nil
+}
+
+// Add appends an already captured snapshot, e.g. one loaded from disk.
+// It returns false if a snapshot with the same skin version was already recorded for the user.
+func (t *SkinTimeline) Add(snapshot SkinSnapshot) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	snapshots := t.users[snapshot.UserID]
+	if hasSkinVersion(snapshots, snapshot.SkinVersion) {
+		return false
+	}
+	t.users[snapshot.UserID] = append(snapshots, snapshot)
+	return true
+}
+
+// hasSkinVersion reports whether one of the snapshots has the given skin version.
+func hasSkinVersion(snapshots []SkinSnapshot, version string) bool {
+	for _, snapshot := range snapshots {
+		if snapshot.SkinVersion == version {
+			return true
+		}
+	}
+	return false
+}
+
+// Snapshots returns a copy of the snapshots recorded for a user, oldest first.
+func (t *SkinTimeline) Snapshots(userID string) []SkinSnapshot {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]SkinSnapshot(nil), t.users[userID]...)
+}
+
+// --- Export ---
+
+// ErrEmptyTimeline is returned when exporting a timeline without any snapshot.
+var ErrEmptyTimeline = errors.New("timeline has no snapshots")
+
+// decodeSnapshots decodes every snapshot and draws them onto same-sized RGBA frames,
+// anchored at the bottom so that characters of different heights stand on the same line.
+func decodeSnapshots(snapshots []SkinSnapshot) ([]*image.NRGBA, error) {
+	if len(snapshots) == 0 {
+		return nil, ErrEmptyTimeline
+	}
+
+	decoded := make([]image.Image, len(snapshots))
+	width, height := 0, 0
+	for i, snapshot := range snapshots {
+		img, err := png.Decode(bytes.NewReader(snapshot.Image))
+		if err != nil {
+			return nil, fmt.Errorf("snapshot %s is not a valid PNG: %w", snapshot.SkinVersion, err)
+		}
+		decoded[i] = img
+		width = max(width, img.Bounds().Dx())
+		height = max(height, img.Bounds().Dy())
+	}
+
+	frames := make([]*image.NRGBA, len(decoded))
+	for i, img := range decoded {
+		frame := image.NewNRGBA(image.Rect(0, 0, width, height))
+		b := img.Bounds()
+		offset := image.Pt((width-b.Dx())/2, height-b.Dy())
+		draw.Draw(frame, b.Sub(b.Min).Add(offset), img, b.Min, draw.Src)
+		frames[i] = frame
+	}
+	return frames, nil
+}
+
+// EncodeTimelineGIF writes the snapshots as a looping animated GIF, showing each look for the given delay.
+func EncodeTimelineGIF(w io.Writer, snapshots []SkinSnapshot, delay time.Duration) error {
+	frames, err := decodeSnapshots(snapshots)
+	if err != nil {
+		return err
+	}
+
+	// GIF delays are expressed in hundredths of a second.
+	centiseconds := int(delay / (10 * time.Millisecond))
+	anim := &gif.GIF{}
+	for _, frame := range frames {
+		// Index 0 of the palette is kept transparent for the background.
+		pal := append(color.Palette{color.Transparent}, palette.Plan9[:255]...)
+		paletted := image.NewPaletted(frame.Bounds(), pal)
+		draw.FloydSteinberg.Draw(paletted, frame.Bounds(), frame, image.Point{})
+		anim.Image = append(anim.Image, paletted)
+		anim.Delay = append(anim.Delay, centiseconds)
+		anim.Disposal = append(anim.Disposal, gif.DisposalBackground)
+	}
+	return gif.EncodeAll(w, anim)
+}
+
+// EncodeTimelineAPNG writes the snapshots as a looping animated PNG, showing each look for the given delay.
+// Unlike GIF, APNG keeps the full colors and alpha channel of the original renders.
+func EncodeTimelineAPNG(w io.Writer, snapshots []SkinSnapshot, delay time.Duration) error {
+	frames, err := decodeSnapshots(snapshots)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	width, height := frames[0].Bounds().Dx(), frames[0].Bounds().Dy()
+
+	// 1. PNG signature and header: 8-bit RGBA, no interlacing.
+	bw.WriteString("\x89PNG\r\n\x1a\n")
+	ihdr := make([]byte, 13)
+	binary.BigEndian.PutUint32(ihdr[0:], uint32(width))
+	binary.BigEndian.PutUint32(ihdr[4:], uint32(height))
+	ihdr[8] = 8 // bit depth
+	ihdr[9] = 6 // color type: truecolor with alpha
+	writePNGChunk(bw, "IHDR", ihdr)
+
+	// 2. Animation control: frame count, and zero plays meaning "loop forever".
+	actl := make([]byte, 8)
+	binary.BigEndian.PutUint32(actl[0:], uint32(len(frames)))
+	writePNGChunk(bw, "acTL", actl)
+
+	// 3. One frame control chunk per frame, followed by its data.
+	// The first frame's data goes in IDAT so non-APNG viewers show it as a still image.
+	sequence := uint32(0)
+	delayMs := uint16(min(delay.Milliseconds(), 65535))
+	for i, frame := range frames {
+		fctl := make([]byte, 26)
+		binary.BigEndian.PutUint32(fctl[0:], sequence)
+		binary.BigEndian.PutUint32(fctl[4:], uint32(width))
+		binary.BigEndian.PutUint32(fctl[8:], uint32(height))
+		binary.BigEndian.PutUint16(fctl[20:], delayMs)
+		binary.BigEndian.PutUint16(fctl[22:], 1000)
+		fctl[24] = 1 // dispose to background
+		writePNGChunk(bw, "fcTL", fctl)
+		sequence++
+
+		data, err := compressPNGFrame(frame)
+		if err != nil {
+			return err
+		}
+		if i == 0 {
+			writePNGChunk(bw, "IDAT", data)
+			continue
+		}
+		fdat := make([]byte, 4, 4+len(data))
+		binary.BigEndian.PutUint32(fdat, sequence)
+		writePNGChunk(bw, "fdAT", append(fdat, data...))
+		sequence++
+	}
+
+	writePNGChunk(bw, "IEND", nil)
+	return bw.Flush()
+}
+
+// compressPNGFrame returns the zlib-compressed scanlines of an RGBA frame, as stored in IDAT/fdAT chunks.
+func compressPNGFrame(frame *image.NRGBA) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	b := frame.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		// Each scanline starts with its filter type; 0 means unfiltered.
+		row := frame.Pix[frame.PixOffset(b.Min.X, y):frame.PixOffset(b.Max.X, y)]
+		if _, err := zw.Write([]byte{0}); err != nil {
+			return nil, err
+		}
+		if _, err := zw.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writePNGChunk writes a length-prefixed, CRC-terminated PNG chunk.
+// Errors are deferred to the final Flush of the buffered writer.
+func writePNGChunk(w *bufio.Writer, chunkType string, data []byte) {
+	var header [8]byte
+	binary.BigEndian.PutUint32(header[:4], uint32(len(data)))
+	copy(header[4:], chunkType)
+	w.Write(header[:])
+	w.Write(data)
+
+	crc := crc32.NewIEEE()
+	crc.Write(header[4:])
+	crc.Write(data)
+	var footer [4]byte
+	binary.BigEndian.PutUint32(footer[:], crc.Sum32())
+	w.Write(footer[:])
+}
+
+// TimelineContactSheet lays the snapshots out on a grid with the given number of columns,
+// each render labelled with the date it was first observed (YYYY-MM-DD).
+func TimelineContactSheet(snapshots []SkinSnapshot, columns int) (image.Image, error) {
+	frames, err := decodeSnapshots(snapshots)
+	if err != nil {
+		return nil, err
+	}
+	if columns <= 0 {
+		columns = 4
+	}
+	columns = min(columns, len(frames))
+	rows := (len(frames) + columns - 1) / columns
+
+	const (
+		padding    = 8
+		labelScale = 2
+	)
+	labelHeight := glyphHeight*labelScale + padding
+	cellWidth := frames[0].Bounds().Dx() + padding
+	cellHeight := frames[0].Bounds().Dy() + labelHeight + padding
+
+	sheet := image.NewNRGBA(image.Rect(0, 0, columns*cellWidth+padding, rows*cellHeight+padding))
+	draw.Draw(sheet, sheet.Bounds(), image.White, image.Point{}, draw.Src)
+
+	for i, frame := range frames {
+		x := padding + (i%columns)*cellWidth
+		y := padding + (i/columns)*cellHeight
+		draw.Draw(sheet, frame.Bounds().Add(image.Pt(x, y)), frame, image.Point{}, draw.Over)
+
+		label := snapshots[i].ObservedAt.Format("2006-01-02")
+		labelX := x + (frame.Bounds().Dx()-textWidth(label)*labelScale)/2
+		drawText(sheet, label, labelX, y+frame.Bounds().Dy()+padding/2, labelScale, color.Black)
+	}
+	return sheet, nil
+}
+
+// --- Minimal bitmap font for date labels ---
+
+const (
+	glyphWidth  = 3
+	glyphHeight = 5
+)
+
+// glyphs is a 3x5 pixel font covering the characters used in dates and times.
+// Each row is a 3-bit mask, most significant bit on the left.
+var glyphs = map[rune][glyphHeight]uint8{
+	'0': {7, 5, 5, 5, 7},
+	'1': {2, 6, 2, 2, 7},
+	'2': {7, 1, 7, 4, 7},
+	'3': {7, 1, 7, 1, 7},
+	'4': {5, 5, 7, 1, 1},
+	'5': {7, 4, 7, 1, 7},
+	'6': {7, 4, 7, 5, 7},
+	'7': {7, 1, 1, 1, 1},
+	'8': {7, 5, 7, 5, 7},
+	'9': {7, 5, 7, 1, 7},
+	'-': {0, 0, 7, 0, 0},
+	':': {0, 2, 0, 2, 0},
+	'/': {1, 1, 2, 4, 4},
+	' ': {0, 0, 0, 0, 0},
+}
+
+// textWidth returns the unscaled pixel width of a label.
+func textWidth(text string) int {
+	n := len([]rune(text))
+	if n == 0 {
+		return 0
+	}
+	return n*(glyphWidth+1) - 1
+}
+
+// drawText draws a label at (x, y) with each font pixel scaled to a square of the given size.
+// Characters missing from the font are drawn as blanks.
+func drawText(dst draw.Image, text string, x, y, scale int, c color.Color) {
+	src := image.NewUniform(c)
+	for _, r := range text {
+		glyph := glyphs[r]
+		for row := 0; row < glyphHeight; row++ {
+			for col := 0; col < glyphWidth; col++ {
+				if glyph[row]&(1<<(glyphWidth-1-col)) == 0 {
+					continue
+				}
+				px := image.Rect(x+col*scale, y+row*scale, x+(col+1)*scale, y+(row+1)*scale)
+				draw.Draw(dst, px, src, image.Point{}, draw.Src)
+			}
+		}
+		x += (glyphWidth + 1) * scale
+	}
+}
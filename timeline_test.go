@@ -0,0 +1,148 @@
+package wolfyclient
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"testing"
+	"time"
+)
+
+// testSnapshot returns a snapshot whose render is a w×h PNG filled with c.
+func testSnapshot(t *testing.T, version string, w, h int, c color.Color) SkinSnapshot {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return SkinSnapshot{
+		UserID:      "1",
+		SkinVersion: version,
+		ObservedAt:  time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC),
+		Image:       buf.Bytes(),
+	}
+}
+
+func testSnapshots(t *testing.T) []SkinSnapshot {
+	return []SkinSnapshot{
+		testSnapshot(t, "a", 10, 20, color.NRGBA{R: 255, A: 255}),
+		testSnapshot(t, "b", 12, 16, color.NRGBA{G: 255, A: 255}),
+		testSnapshot(t, "c", 8, 20, color.NRGBA{B: 255, A: 128}),
+	}
+}
+
+func TestSkinTimelineAddDeduplicatesVersions(t *testing.T) {
+	timeline := NewSkinTimeline(nil)
+	for _, version := range []string{"a", "b", "a", "b", "c"} {
+		timeline.Add(SkinSnapshot{UserID: "1", SkinVersion: version})
+	}
+
+	var versions []string
+	for _, snapshot := range timeline.Snapshots("1") {
+		versions = append(versions, snapshot.SkinVersion)
+	}
+	if got := fmt.Sprint(versions); got != "[a b c]" {
+		t.Errorf("recorded versions = %s, want [a b c]", got)
+	}
+}
+
+func TestEncodeTimelineGIF(t *testing.T) {
+	var buf bytes.Buffer
+	if err := EncodeTimelineGIF(&buf, testSnapshots(t), 750*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	anim, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatalf("DecodeAll: %v", err)
+	}
+	if len(anim.Image) != 3 {
+		t.Fatalf("got %d frames, want 3", len(anim.Image))
+	}
+	for i, frame := range anim.Image {
+		if anim.Delay[i] != 75 {
+			t.Errorf("frame %d delay = %d, want 75", i, anim.Delay[i])
+		}
+		if got := frame.Bounds(); got != image.Rect(0, 0, 12, 20) {
+			t.Errorf("frame %d bounds = %v, want 12x20", i, got)
+		}
+	}
+}
+
+func TestEncodeTimelineAPNG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := EncodeTimelineAPNG(&buf, testSnapshots(t), 750*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	// Viewers without APNG support must still see the first frame.
+	first, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("png.Decode: %v", err)
+	}
+	if got := first.Bounds(); got != image.Rect(0, 0, 12, 20) {
+		t.Errorf("bounds = %v, want 12x20", got)
+	}
+
+	// Walk the chunks to check the animation control data.
+	var frameCount uint32
+	var delays []int
+	var fdats int
+	for pos := 8; pos+8 <= len(data); {
+		length := int(binary.BigEndian.Uint32(data[pos:]))
+		chunkType := string(data[pos+4 : pos+8])
+		chunk := data[pos+8 : pos+8+length]
+		switch chunkType {
+		case "acTL":
+			frameCount = binary.BigEndian.Uint32(chunk)
+		case "fcTL":
+			num, den := binary.BigEndian.Uint16(chunk[20:]), binary.BigEndian.Uint16(chunk[22:])
+			delays = append(delays, int(num)*1000/int(den))
+		case "fdAT":
+			fdats++
+		}
+		pos += 12 + length
+	}
+	if frameCount != 3 {
+		t.Errorf("acTL frame count = %d, want 3", frameCount)
+	}
+	if len(delays) != 3 || fdats != 2 {
+		t.Fatalf("got %d fcTL and %d fdAT chunks, want 3 and 2", len(delays), fdats)
+	}
+	for i, delay := range delays {
+		if delay != 750 {
+			t.Errorf("frame %d delay = %dms, want 750ms", i, delay)
+		}
+	}
+}
+
+func TestTimelineContactSheet(t *testing.T) {
+	sheet, err := TimelineContactSheet(testSnapshots(t), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Two columns and two rows of 12x20 frames, each cell padded and labelled.
+	const padding = 8
+	cellWidth := 12 + padding
+	cellHeight := 20 + glyphHeight*2 + padding + padding
+	want := image.Rect(0, 0, 2*cellWidth+padding, 2*cellHeight+padding)
+	if got := sheet.Bounds(); got != want {
+		t.Errorf("bounds = %v, want %v", got, want)
+	}
+
+	if _, err := TimelineContactSheet(nil, 2); err != ErrEmptyTimeline {
+		t.Errorf("empty timeline error = %v, want ErrEmptyTimeline", err)
+	}
+}
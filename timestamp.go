@@ -0,0 +1,139 @@
+package wolfyclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timestampLayouts lists the date formats seen in Wolfy payloads, tried in order.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",       // ISO without a zone, assumed UTC.
+	"2006-01-02 15:04:05.999999999Z07:00", // SQL style with a zone.
+	"2006-01-02 15:04:05.999999999 -07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// jsTimeLayout is the format produced by JavaScript's Date.toISOString, used by the API.
+const jsTimeLayout = "2006-01-02T15:04:05.000Z07:00"
+
+// Timestamp is a point in time decoded leniently from the formats used by the Wolfy API:
+// ISO 8601 strings with or without a zone, SQL-style dates, and Unix timestamps in
+// seconds or milliseconds, integer or fractional. A null or empty value decodes to the zero time.
+//
+// The original JSON value is remembered, so re-encoding an unmodified Timestamp
+// produces exactly the bytes that were received. Because of this, == also compares the
+// original representation: the same instant received as 1709647629 and as an ISO string
+// gives two Timestamps that are not ==. Compare instants with Equal, Before and After,
+// e.g. a.Equal(b.Time).
+type Timestamp struct {
+	time.Time
+
+	raw     string    // The JSON value this timestamp was decoded from; a string keeps Timestamp comparable.
+	rawTime time.Time // The time raw decoded to, to detect later modifications.
+}
+
+// NewTimestamp wraps a time.Time.
+func NewTimestamp(t time.Time) Timestamp {
+	return Timestamp{Time: t}
+}
+
+// ParseTimestamp parses a timestamp string in any of the formats accepted when decoding JSON.
+func ParseTimestamp(value string) (Timestamp, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return Timestamp{}, nil
+	}
+
+	// Numeric values are Unix timestamps; anything past year 33658 in seconds is taken as milliseconds.
+	if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if n > 1e12 || n < -1e12 {
+			return Timestamp{Time: time.UnixMilli(n).UTC()}, nil
+		}
+		return Timestamp{Time: time.Unix(n, 0).UTC()}, nil
+	}
+	// Fractional or exponent forms such as 1709647629.5 or 1.7e9, rounded to the millisecond.
+	// The bound keeps the conversion to int64 milliseconds from overflowing.
+	if f, err := strconv.ParseFloat(value, 64); err == nil && math.Abs(f) < 1e15 {
+		if f > 1e12 || f < -1e12 {
+			return Timestamp{Time: time.UnixMilli(int64(math.Round(f))).UTC()}, nil
+		}
+		return Timestamp{Time: time.UnixMilli(int64(math.Round(f * 1000))).UTC()}, nil
+	}
+
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return Timestamp{Time: t}, nil
+		}
+	}
+	return Timestamp{}, fmt.Errorf("unrecognized timestamp format '%s'", value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+
+	var value string
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		value = ""
+	case len(data) > 0 && data[0] == '"':
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+	default:
+		value = string(data)
+	}
+
+	parsed, err := ParseTimestamp(value)
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	t.raw = string(data)
+	t.rawTime = parsed.Time
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// Unmodified timestamps are written back in their original form; others are written
+// in the same ISO 8601 format as the API, or as null for the zero time.
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.raw != "" && t.Time.Equal(t.rawTime) {
+		return []byte(t.raw), nil
+	}
+	if t.Time.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(strconv.Quote(t.Time.UTC().Format(jsTimeLayout))), nil
+}
+
+// MarshalText implements encoding.TextMarshaler, e.g. for timestamps used as map keys.
+// It writes the same ISO 8601 format as String, and nothing for the zero time.
+func (t Timestamp) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting any format ParseTimestamp does.
+func (t *Timestamp) UnmarshalText(data []byte) error {
+	parsed, err := ParseTimestamp(string(data))
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+// String returns the timestamp in the API's ISO 8601 format, or an empty string for the zero time.
+func (t Timestamp) String() string {
+	if t.Time.IsZero() {
+		return ""
+	}
+	return t.Time.UTC().Format(jsTimeLayout)
+}
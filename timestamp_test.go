@@ -0,0 +1,154 @@
+package wolfyclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimestampUnmarshal(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want time.Time
+	}{
+		{"iso with millis", `"2024-03-05T14:07:09.123Z"`, time.Date(2024, 3, 5, 14, 7, 9, 123e6, time.UTC)},
+		{"iso with offset", `"2024-03-05T15:07:09+01:00"`, time.Date(2024, 3, 5, 14, 7, 9, 0, time.UTC)},
+		{"iso without zone", `"2024-03-05T14:07:09"`, time.Date(2024, 3, 5, 14, 7, 9, 0, time.UTC)},
+		{"sql with zone", `"2024-03-05 14:07:09+00:00"`, time.Date(2024, 3, 5, 14, 7, 9, 0, time.UTC)},
+		{"sql with spaced zone", `"2024-03-05 14:07:09 +00:00"`, time.Date(2024, 3, 5, 14, 7, 9, 0, time.UTC)},
+		{"sql without zone", `"2024-03-05 14:07:09"`, time.Date(2024, 3, 5, 14, 7, 9, 0, time.UTC)},
+		{"date only", `"2024-03-05"`, time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{"unix seconds", `1709647629`, time.Date(2024, 3, 5, 14, 7, 9, 0, time.UTC)},
+		{"unix milliseconds", `1709647629123`, time.Date(2024, 3, 5, 14, 7, 9, 123e6, time.UTC)},
+		{"quoted unix seconds", `"1709647629"`, time.Date(2024, 3, 5, 14, 7, 9, 0, time.UTC)},
+		{"fractional unix seconds", `1709647629.123`, time.Date(2024, 3, 5, 14, 7, 9, 123e6, time.UTC)},
+		{"exponent unix seconds", `1.7e9`, time.Unix(1.7e9, 0)},
+		{"fractional unix milliseconds", `1709647629123.0`, time.Date(2024, 3, 5, 14, 7, 9, 123e6, time.UTC)},
+		{"null", `null`, time.Time{}},
+		{"empty string", `""`, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ts Timestamp
+			if err := json.Unmarshal([]byte(tt.json), &ts); err != nil {
+				t.Fatalf("Unmarshal(%s): %v", tt.json, err)
+			}
+			if !ts.Time.Equal(tt.want) {
+				t.Errorf("Unmarshal(%s) = %v, want %v", tt.json, ts.Time, tt.want)
+			}
+
+			// Unmodified timestamps must round-trip to the exact bytes received.
+			out, err := json.Marshal(ts)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			if string(out) != tt.json {
+				t.Errorf("round trip of %s = %s", tt.json, out)
+			}
+		})
+	}
+}
+
+func TestTimestampUnmarshalInvalid(t *testing.T) {
+	var ts Timestamp
+	for _, value := range []string{`"yesterday"`, `"NaN"`, `1e300`} {
+		if err := json.Unmarshal([]byte(value), &ts); err == nil {
+			t.Errorf("Unmarshal(%s) succeeded with %v", value, ts.Time)
+		}
+	}
+}
+
+func TestTimestampText(t *testing.T) {
+	var ts Timestamp
+	if err := ts.UnmarshalText([]byte("1709647629")); err != nil {
+		t.Fatal(err)
+	}
+	out, err := ts.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "2024-03-05T14:07:09.000Z"; string(out) != want {
+		t.Errorf("MarshalText = %s, want %s", out, want)
+	}
+
+	// Timestamps used as map keys are encoded through the text methods.
+	in := map[Timestamp]int{ts: 1}
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var back map[Timestamp]int
+	if err := json.Unmarshal(data, &back); err != nil {
+		t.Fatal(err)
+	}
+	for key := range back {
+		if !key.Equal(ts.Time) {
+			t.Errorf("map key round trip = %v, want %v", key, ts)
+		}
+	}
+}
+
+func TestTimestampEqualAcrossFormats(t *testing.T) {
+	var a, b Timestamp
+	if err := json.Unmarshal([]byte(`1709647629`), &a); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`"2024-03-05T14:07:09Z"`), &b); err != nil {
+		t.Fatal(err)
+	}
+	if !a.Equal(b.Time) {
+		t.Errorf("%v and %v are not the same instant", a, b)
+	}
+}
+
+func TestSlotOmitsMissingTimestamps(t *testing.T) {
+	out, err := json.Marshal(Slot{ID: "1", Price: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(out, []byte("createdAt")) || bytes.Contains(out, []byte("updatedAt")) {
+		t.Errorf("locked slot encoded timestamps: %s", out)
+	}
+}
+
+func TestTimestampMarshalModified(t *testing.T) {
+	var ts Timestamp
+	if err := json.Unmarshal([]byte(`1709647629`), &ts); err != nil {
+		t.Fatal(err)
+	}
+	ts.Time = ts.Time.Add(time.Second)
+
+	out, err := json.Marshal(ts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `"2024-03-05T14:07:10.000Z"`; string(out) != want {
+		t.Errorf("Marshal = %s, want %s", out, want)
+	}
+
+	if out, _ := json.Marshal(Timestamp{}); string(out) != "null" {
+		t.Errorf("Marshal of the zero timestamp = %s, want null", out)
+	}
+}
+
+func TestTimestampComparable(t *testing.T) {
+	var a, b Timestamp
+	if err := json.Unmarshal([]byte(`"2024-03-05T14:07:09.123Z"`), &a); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`"2024-03-05T14:07:09.123Z"`), &b); err != nil {
+		t.Fatal(err)
+	}
+	if a != b {
+		t.Errorf("timestamps decoded from the same value are not equal")
+	}
+
+	// Types embedding timestamps must stay usable as map keys.
+	seen := map[PlayerUser]bool{{ID: "1", CreatedAt: a}: true}
+	if !seen[PlayerUser{ID: "1", CreatedAt: b}] {
+		t.Errorf("PlayerUser with equal timestamps is not found in a map")
+	}
+}
@@ -1,5 +1,7 @@
 package wolfyclient
 
+import "encoding/json"
+
 // --- Request Structs (for application/x-www-form-urlencoded encoding) ---
 
 // ChangeUsernameRequest is the request payload for changing a user's username.
@@ -18,6 +20,23 @@ type ChangePasswordRequest struct {
 	NewPassword string `url:"newPass"`
 }
 
+// UpdateSettingsRequest is the request payload for changing account preferences.
+// Nil fields are left unchanged.
+type UpdateSettingsRequest struct {
+	AllowFriendRequests *bool              `url:"allowFriendRequests,omitempty"`
+	AllowGroupRequests  *bool              `url:"allowGroupRequests,omitempty"`
+	AllowNewsletter     *bool              `url:"allowNewsletter,omitempty"`
+	FriendsVisibility   *FriendsVisibility `url:"friendsVisibility,omitempty"`
+	Lang                *string            `url:"lang,omitempty"`
+}
+
+// LeaderboardQuery holds the query parameters of the global leaderboard endpoint.
+type LeaderboardQuery struct {
+	Type LeaderboardType `url:"type"`
+	Lang string          `url:"lang,omitempty"`
+	Page int             `url:"page,omitempty"`
+}
+
 // --- Response Structs (for JSON decoding) ---
 
 // MessageResponse is a generic response containing a single message string.
@@ -50,7 +69,7 @@ type PlayerUser struct {
 	SlotID           string        `json:"slotId"`
 	SkinVersion      string        `json:"skinVersion"`
 	Elo              int           `json:"elo"`
-	CreatedAt        string        `json:"createdAt"`
+	CreatedAt        Timestamp     `json:"createdAt"`
 	MonthsSubscribed int           `json:"monthsSubscribed"`
 	GamePlayed       int           `json:"gamePlayed"`
 	Ranking          PlayerRanking `json:"ranking"`
@@ -64,7 +83,7 @@ type PlayerRanking struct {
 
 // RoleStats contains win rate and advanced statistics for a specific role.
 type RoleStats struct {
-	ID            string             `json:"id"`
+	ID            Role               `json:"id"`
 	WinRate       float64            `json:"winRate"`
 	AdvancedStats map[string]float64 `json:"advancedStats"`
 }
@@ -80,7 +99,7 @@ type GameTypeAdvancedStats struct {
 
 // GameTypeStats contains the overall win rate and stats for an alignment.
 type GameTypeStats struct {
-	ID            string                `json:"id"`
+	ID            Alignment             `json:"id"`
 	WinRate       float64               `json:"winRate"`
 	AdvancedStats GameTypeAdvancedStats `json:"advancedStats"`
 }
@@ -109,10 +128,10 @@ type PlayerIndividualStats struct {
 
 // GameSettings holds the specific rules and role composition for a game.
 type GameSettings struct {
-	Slots     int            `json:"slots"`
-	Mayor     bool           `json:"mayor"`
-	Roles     map[string]int `json:"roles"`
-	Balancing int            `json:"balancing"`
+	Slots     int          `json:"slots"`
+	Mayor     bool         `json:"mayor"`
+	Roles     map[Role]int `json:"roles"`
+	Balancing int          `json:"balancing"`
 }
 
 // Game holds detailed information about a specific match instance.
@@ -127,26 +146,26 @@ type Game struct {
 	Serious     bool         `json:"serious"`
 	Platform    string       `json:"platform"`
 	Lang        string       `json:"lang"`
-	CreatedAt   string       `json:"createdAt"`
-	UpdatedAt   string       `json:"updatedAt"`
-	NextID      interface{}  `json:"nextId"` // Can be null
+	CreatedAt   Timestamp    `json:"createdAt"`
+	UpdatedAt   Timestamp    `json:"updatedAt"`
+	NextID      *string      `json:"nextId"` // Can be null
 	AdminID     string       `json:"adminId"`
 }
 
 // DeathReason provides details on how a player died in a game.
 // Fields are optional as they depend on the type of death.
 type DeathReason struct {
-	Type      string   `json:"type"`
-	DayNumber int      `json:"dayNumber"`
-	VotersIDs []string `json:"votersIds,omitempty"`
-	HunterID  string   `json:"hunterId,omitempty"`
-	MayorID   string   `json:"mayorId,omitempty"`
-	LoverID   string   `json:"loverId,omitempty"`
+	Type      DeathType `json:"type"`
+	DayNumber int       `json:"dayNumber"`
+	VotersIDs []string  `json:"votersIds,omitempty"`
+	HunterID  string    `json:"hunterId,omitempty"`
+	MayorID   string    `json:"mayorId,omitempty"`
+	LoverID   string    `json:"loverId,omitempty"`
 }
 
 // GameHistoryEntry represents a single game played by the user in their history.
 type GameHistoryEntry struct {
-	Role        string       `json:"role"`
+	Role        Role         `json:"role"`
 	Winner      bool         `json:"winner"`
 	DeathReason *DeathReason `json:"deathReason"` // Pointer to handle null
 	WordCount   int          `json:"wordCount"`
@@ -157,8 +176,8 @@ type GameHistoryEntry struct {
 	Infected    bool         `json:"infected"`
 	UserID      string       `json:"userId"`
 	GameID      string       `json:"gameId"`
-	CreatedAt   string       `json:"createdAt"`
-	UpdatedAt   string       `json:"updatedAt"`
+	CreatedAt   Timestamp    `json:"createdAt"`
+	UpdatedAt   Timestamp    `json:"updatedAt"`
 	Game        Game         `json:"game"`
 }
 
@@ -183,68 +202,100 @@ type Skin struct {
 
 // Slot represents a single skin slot, which can be locked or unlocked.
 type Slot struct {
-	Unlocked    bool   `json:"unlocked"`
-	ID          string `json:"id"`
-	OfferID     string `json:"offerId,omitempty"` // Only in unlocked slots
-	SkinVersion string `json:"skinVersion,omitempty"`
-	CreatedAt   string `json:"createdAt,omitempty"`
-	UpdatedAt   string `json:"updatedAt,omitempty"`
-	UserID      string `json:"userId,omitempty"`
-	Skin        *Skin  `json:"skin,omitempty"` // Pointer to handle null
-	Equiped     bool   `json:"equiped,omitempty"`
-	Price       int    `json:"price,omitempty"` // Only in locked slots
-	Currency    string `json:"currency,omitempty"`
-	Alpha       bool   `json:"alpha,omitempty"`
+	Unlocked    bool       `json:"unlocked"`
+	ID          string     `json:"id"`
+	OfferID     string     `json:"offerId,omitempty"` // Only in unlocked slots
+	SkinVersion string     `json:"skinVersion,omitempty"`
+	CreatedAt   *Timestamp `json:"createdAt,omitempty"` // Only in unlocked slots
+	UpdatedAt   *Timestamp `json:"updatedAt,omitempty"` // Only in unlocked slots
+	UserID      string     `json:"userId,omitempty"`
+	Skin        *Skin      `json:"skin,omitempty"` // Pointer to handle null
+	Equiped     bool       `json:"equiped,omitempty"`
+	Price       int        `json:"price,omitempty"` // Only in locked slots
+	Currency    Currency   `json:"currency,omitempty"`
+	Alpha       bool       `json:"alpha,omitempty"`
+}
+
+// BanInfo summarizes the ban state of an account.
+type BanInfo struct {
+	Banned bool
+	End    Timestamp // Zero for a permanent ban.
+	Reason string
+}
+
+// LinkedAccount is a third-party login connected to a Wolfy account.
+type LinkedAccount struct {
+	Provider string // "twitter", "facebook", "google", "discord" or "apple".
+	ID       string
 }
 
 // TokenInfo contains details about the user's current session token.
 type TokenInfo struct {
-	ID        string      `json:"id"`
-	TwoFactor interface{} `json:"twoFactor"`
+	ID        string `json:"id"`
+	TwoFactor *bool  `json:"twoFactor"`
 }
 
 // UserAccountInfo is the top-level response from the /user endpoint,
 // containing detailed private information for the authenticated user.
 type UserAccountInfo struct {
-	ID                  string      `json:"id"`
-	Username            string      `json:"username"`
-	Email               string      `json:"email"`
-	TwitterID           interface{} `json:"twitterId"`
-	FacebookID          interface{} `json:"facebookId"`
-	GoogleID            interface{} `json:"googleId"`
-	DiscordID           interface{} `json:"discordId"`
-	AppleID             interface{} `json:"appleId"`
-	ProfilePicture      string      `json:"profilePicture"`
-	XP                  int         `json:"xp"`
-	Elo                 int         `json:"elo"`
-	Coins               int         `json:"coins"`
-	Moons               int         `json:"moons"`
-	Rank                int         `json:"rank"`
-	SkinVersion         string      `json:"skinVersion"`
-	SkinIndex           int         `json:"skinIndex"`
-	AnonymousSkinIndex  int         `json:"anonymousSkinIndex"`
-	SlotID              string      `json:"slotId"`
-	AnonymousSlotID     interface{} `json:"anonymousSlotId"`
-	AllowFriendRequests bool        `json:"allowFriendRequests"`
-	AllowGroupRequests  bool        `json:"allowGroupRequests"`
-	AllowNewsletter     bool        `json:"allowNewsletter"`
-	Nickname            interface{} `json:"nickname"`
-	Confirmed           bool        `json:"confirmed"`
-	DiscountEndAt       interface{} `json:"discountEndAt"`
-	TwoFactorSecret     bool        `json:"twoFactorSecret"`
-	Lang                string      `json:"lang"`
-	BanEnd              interface{} `json:"ban_end"`
-	ReasonBan           interface{} `json:"reason_ban"`
-	NeedRename          bool        `json:"needRename"`
-	Banned              bool        `json:"banned"`
-	FriendsVisibility   string      `json:"friendsVisibility"`
-	AlphaLegacy         bool        `json:"alphaLegacy"`
-	Password            bool        `json:"password"`
-	Token               TokenInfo   `json:"token"`
-	Slots               []Slot      `json:"slots"`
-	Skin                Skin        `json:"skin"`
-	Features            []string    `json:"features"`
-	Subscription        interface{} `json:"subscription"`
+	ID                  string            `json:"id"`
+	Username            string            `json:"username"`
+	Email               string            `json:"email"`
+	TwitterID           *string           `json:"twitterId"`
+	FacebookID          *string           `json:"facebookId"`
+	GoogleID            *string           `json:"googleId"`
+	DiscordID           *string           `json:"discordId"`
+	AppleID             *string           `json:"appleId"`
+	ProfilePicture      string            `json:"profilePicture"`
+	XP                  int               `json:"xp"`
+	Elo                 int               `json:"elo"`
+	Coins               int               `json:"coins"`
+	Moons               int               `json:"moons"`
+	Rank                int               `json:"rank"`
+	SkinVersion         string            `json:"skinVersion"`
+	SkinIndex           int               `json:"skinIndex"`
+	AnonymousSkinIndex  int               `json:"anonymousSkinIndex"`
+	SlotID              string            `json:"slotId"`
+	AnonymousSlotID     *string           `json:"anonymousSlotId"`
+	AllowFriendRequests bool              `json:"allowFriendRequests"`
+	AllowGroupRequests  bool              `json:"allowGroupRequests"`
+	AllowNewsletter     bool              `json:"allowNewsletter"`
+	Nickname            *string           `json:"nickname"`
+	Confirmed           bool              `json:"confirmed"`
+	DiscountEndAt       Timestamp         `json:"discountEndAt"`
+	TwoFactorSecret     bool              `json:"twoFactorSecret"`
+	Lang                string            `json:"lang"`
+	BanEnd              Timestamp         `json:"ban_end"`
+	ReasonBan           *string           `json:"reason_ban"`
+	NeedRename          bool              `json:"needRename"`
+	Banned              bool              `json:"banned"`
+	FriendsVisibility   FriendsVisibility `json:"friendsVisibility"`
+	AlphaLegacy         bool              `json:"alphaLegacy"`
+	Password            bool              `json:"password"`
+	Token               TokenInfo         `json:"token"`
+	Slots               []Slot            `json:"slots"`
+	Skin                Skin              `json:"skin"`
+	Features            []string          `json:"features"`
+	Subscription        json.RawMessage   `json:"subscription"` // Shape not documented yet; null without a subscription.
+}
+
+// FriendRequest is a pending friend request, either received or sent by the authenticated user.
+// The user fields describe the other party of the request.
+type FriendRequest struct {
+	ID          string    `json:"id"`
+	Username    string    `json:"username"`
+	XP          int       `json:"xp"`
+	Rank        int       `json:"rank"`
+	Elo         int       `json:"elo"`
+	SlotID      string    `json:"slotId"`
+	SkinVersion string    `json:"skinVersion"`
+	CreatedAt   Timestamp `json:"createdAt"`
+}
+
+// FriendRequests is the response from the /social/requests endpoint.
+type FriendRequests struct {
+	Incoming []FriendRequest `json:"incoming"`
+	Outgoing []FriendRequest `json:"outgoing"`
 }
 
 // LeaderboardEntry represents a single user's summary on the main leaderboard.
@@ -278,26 +329,26 @@ type SkinLayer struct {
 type SkinElement struct {
 	ID          string       `json:"id"`
 	Name        string       `json:"name"`
-	Type        string       `json:"type"`
-	Access      string       `json:"access"`
-	Rarity      string       `json:"rarity"`
+	Type        SkinPartType `json:"type"`
+	Access      Access       `json:"access"`
+	Rarity      Rarity       `json:"rarity"`
 	Level       int          `json:"level"`
 	Price       int          `json:"price"`
 	Colors      [][]string   `json:"colors"`
 	New         bool         `json:"new"`
 	Disposition *Disposition `json:"disposition"` // Pointer to handle null value
-	Currency    string       `json:"currency"`
-	SmallPet    interface{}  `json:"smallPet"` // Type is unknown, can be null
+	Currency    Currency     `json:"currency"`
+	SmallPet    *bool        `json:"smallPet"` // Can be null
 	SkinLayers  []SkinLayer  `json:"skinLayers"`
 	Bought      bool         `json:"bought"`
 }
 
 // PackSkinElementLink contains metadata linking a skin element to a drop pack.
 type PackSkinElementLink struct {
-	CreatedAt     string `json:"createdAt"`
-	UpdatedAt     string `json:"updatedAt"`
-	SkinPackID    int    `json:"skinPackId"`
-	SkinElementID string `json:"skinElementId"`
+	CreatedAt     Timestamp `json:"createdAt"`
+	UpdatedAt     Timestamp `json:"updatedAt"`
+	SkinPackID    int       `json:"skinPackId"`
+	SkinElementID string    `json:"skinElementId"`
 }
 
 // DropSkinElement represents a skin element as part of a drop pack.
@@ -305,18 +356,18 @@ type PackSkinElementLink struct {
 type DropSkinElement struct {
 	ID              string              `json:"id"`
 	Name            string              `json:"name"`
-	Type            string              `json:"type"`
-	Access          string              `json:"access"`
-	Rarity          string              `json:"rarity"`
+	Type            SkinPartType        `json:"type"`
+	Access          Access              `json:"access"`
+	Rarity          Rarity              `json:"rarity"`
 	Level           int                 `json:"level"`
-	Currency        string              `json:"currency"`
+	Currency        Currency            `json:"currency"`
 	Price           int                 `json:"price"`
 	Colors          [][]string          `json:"colors"`
 	New             bool                `json:"new"`
 	Disposition     *Disposition        `json:"disposition"` // Re-using from SkinElement
-	SmallPet        bool                `json:"smallPet"`
-	CreatedAt       string              `json:"createdAt"`
-	UpdatedAt       string              `json:"updatedAt"`
+	SmallPet        *bool               `json:"smallPet"`    // Can be null
+	CreatedAt       Timestamp           `json:"createdAt"`
+	UpdatedAt       Timestamp           `json:"updatedAt"`
 	PackSkinElement PackSkinElementLink `json:"PackSkinElement"`
 }
 
@@ -324,17 +375,17 @@ type DropSkinElement struct {
 type PreviewSkinElement struct {
 	ID          string       `json:"id"`
 	Name        string       `json:"name"`
-	Type        string       `json:"type"`
-	Access      string       `json:"access"`
-	Rarity      string       `json:"rarity"`
+	Type        SkinPartType `json:"type"`
+	Access      Access       `json:"access"`
+	Rarity      Rarity       `json:"rarity"`
 	Level       int          `json:"level"`
-	Currency    string       `json:"currency"`
+	Currency    Currency     `json:"currency"`
 	Price       int          `json:"price"`
 	New         bool         `json:"new"`
 	Disposition *Disposition `json:"disposition"`
-	SmallPet    interface{}  `json:"smallPet"` // Can be null
-	CreatedAt   string       `json:"createdAt"`
-	UpdatedAt   string       `json:"updatedAt"`
+	SmallPet    *bool        `json:"smallPet"` // Can be null
+	CreatedAt   Timestamp    `json:"createdAt"`
+	UpdatedAt   Timestamp    `json:"updatedAt"`
 }
 
 // DropPack represents a single bundle or pack within the current drop.
@@ -343,8 +394,8 @@ type DropPack struct {
 	Name            string                   `json:"name"`
 	Colors          []map[string]interface{} `json:"colors"` // Flexible for varied keys
 	Price           int                      `json:"price"`
-	Rarity          string                   `json:"rarity"`
-	Currency        string                   `json:"currency"`
+	Rarity          Rarity                   `json:"rarity"`
+	Currency        Currency                 `json:"currency"`
 	SkinElements    []DropSkinElement        `json:"SkinElements"`
 	PreviewElements []PreviewSkinElement     `json:"previewElements"`
 	Collected       bool                     `json:"collected"`
@@ -354,19 +405,19 @@ type DropPack struct {
 type CurrentDrop struct {
 	ID        string     `json:"id"`
 	Name      string     `json:"name"`
-	Start     string     `json:"start"`
-	End       string     `json:"end"`
-	CreatedAt string     `json:"createdAt"`
-	UpdatedAt string     `json:"updatedAt"`
+	Start     Timestamp  `json:"start"`
+	End       Timestamp  `json:"end"`
+	CreatedAt Timestamp  `json:"createdAt"`
+	UpdatedAt Timestamp  `json:"updatedAt"`
 	Packs     []DropPack `json:"packs"`
 }
 
 // OfferPack represents a bundle of items within a daily offer.
 type OfferPack struct {
 	Price        int           `json:"price"`
-	Currency     string        `json:"currency"`
+	Currency     Currency      `json:"currency"`
 	ID           int           `json:"id"`
-	Rarity       string        `json:"rarity"`
+	Rarity       Rarity        `json:"rarity"`
 	SkinElements []SkinElement `json:"SkinElements"` // Reusing the existing SkinElement
 }
 
@@ -397,7 +448,7 @@ type OfferElements struct {
 // DailyOfferSet represents the complete set of offers available for a single day.
 type DailyOfferSet struct {
 	ID       int           `json:"id"`
-	End      string        `json:"end"`
+	End      Timestamp     `json:"end"`
 	Elements OfferElements `json:"elements"`
 }
 